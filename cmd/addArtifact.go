@@ -80,19 +80,19 @@ Examples:
 		// Validate required flags
 		if component == "" && addArtifactRelease == "" {
 			fmt.Println("Error: either --component or --release must be specified")
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		if component != "" && version == "" && addArtifactRelease == "" {
 			fmt.Println("Error: --version is required when using --component")
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Check if at least one artifact type is provided
 		hasArtifacts := addArtifactArtifacts != "" || addArtifactReleaseArts != "" || addArtifactDeliverableArts != "" || addArtifactSceArts != ""
 		if !hasArtifacts {
 			fmt.Println("Error: at least one of --artifacts, --releasearts, --deliverablearts, or --scearts must be specified")
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Build GraphQL mutation variables
@@ -110,6 +110,16 @@ Examples:
 			artifactInput["version"] = version
 		}
 
+		// Skip files that are already stored on the target release.
+		targetRelease := addArtifactRelease
+		if targetRelease == "" && !forceUpload {
+			var err error
+			if targetRelease, err = resolveReleaseUuidByVersion(component, version); err != nil {
+				fmt.Fprintln(os.Stderr, "Warning: could not look up the target release, all files will be uploaded:", err)
+			}
+		}
+		prepareUploadedArtifactIndex(targetRelease)
+
 		// Initialize file tracking
 		filesCounter := 0
 		locationMap := make(map[string][]string)
@@ -125,9 +135,10 @@ Examples:
 			var releaseArtsList []Artifact
 			if err := json.Unmarshal([]byte(addArtifactReleaseArts), &releaseArtsList); err != nil {
 				fmt.Printf("Error parsing releasearts JSON: %v\n", err)
-				os.Exit(1)
+				exitCommand(1)
 			}
 
+			releaseArtsList = skipUploadedArtifacts(releaseArtsList, uploadTargetRelease)
 			indexPrefix := "variables.artifactInput.releaseArtifacts."
 			processedArts := processArtifactsInput(&releaseArtsList, indexPrefix, &filesCounter, &locationMap, &filesMap)
 			artifactInput["releaseArtifacts"] = processedArts
@@ -138,11 +149,12 @@ Examples:
 			var deliverableArtsList []DeliverableArtifactGroup
 			if err := json.Unmarshal([]byte(addArtifactDeliverableArts), &deliverableArtsList); err != nil {
 				fmt.Printf("Error parsing deliverablearts JSON: %v\n", err)
-				os.Exit(1)
+				exitCommand(1)
 			}
 
 			// Process each deliverable artifact group
 			for i := range deliverableArtsList {
+				deliverableArtsList[i].Artifacts = skipUploadedArtifacts(deliverableArtsList[i].Artifacts, uploadTargetDeliverable+deliverableArtsList[i].Deliverable)
 				indexPrefix := fmt.Sprintf("variables.artifactInput.deliverableArtifacts.%d.artifacts.", i)
 				processedArts := processArtifactsInput(&deliverableArtsList[i].Artifacts, indexPrefix, &filesCounter, &locationMap, &filesMap)
 				deliverableArtsList[i].Artifacts = *processedArts
@@ -156,11 +168,12 @@ Examples:
 			var sceArtsList []SceArtifactGroup
 			if err := json.Unmarshal([]byte(addArtifactSceArts), &sceArtsList); err != nil {
 				fmt.Printf("Error parsing scearts JSON: %v\n", err)
-				os.Exit(1)
+				exitCommand(1)
 			}
 
 			// Process each SCE artifact group
 			for i := range sceArtsList {
+				sceArtsList[i].Artifacts = skipUploadedArtifacts(sceArtsList[i].Artifacts, uploadTargetSce+":"+sceArtsList[i].Sce)
 				indexPrefix := fmt.Sprintf("variables.artifactInput.sceArtifacts.%d.artifacts.", i)
 				processedArts := processArtifactsInput(&sceArtsList[i].Artifacts, indexPrefix, &filesCounter, &locationMap, &filesMap)
 				sceArtsList[i].Artifacts = *processedArts
@@ -171,76 +184,118 @@ Examples:
 
 		variables["artifactInput"] = artifactInput
 
-		// Build GraphQL mutation
-		mutation := `
-			mutation AddArtifactProgrammatic($artifactInput: AddArtifactInput) {
-				addArtifactProgrammatic(artifactInput: $artifactInput) {
-					uuid
-					version
-					lifecycle
-					artifacts
-				}
-			}
-		`
-
-		// Execute GraphQL mutation
-		body := map[string]interface{}{
-			"query":     mutation,
-			"variables": variables,
+		if len(skippedUploads) > 0 && !hasArtifactsToSend(artifactInput) {
+			// every file is already stored, nothing left to send
+			printSkippedUploads()
+			return
 		}
 
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			fmt.Printf("Error marshaling request: %v\n", err)
-			os.Exit(1)
+		resp, err := postAddArtifact(variables, locationMap, filesMap)
+		if resp == nil {
+			fmt.Printf("Error sending request: %v\n", err)
+			exitCommand(1)
 		}
 
-		if debug == "true" {
-			fmt.Println("GraphQL Request:")
-			fmt.Println(string(jsonBody))
-		}
+		printSkippedUploads()
+		handleResponse(err, resp)
+	},
+}
 
-		// Build GraphQL operation for multipart upload
-		od := make(map[string]interface{})
-		od["operationName"] = "AddArtifactProgrammatic"
-		od["variables"] = variables
-		od["query"] = mutation
-
-		jsonOd, _ := json.Marshal(od)
-		operations := map[string]string{"operations": string(jsonOd)}
-
-		// Build file map
-		fileMapJson, _ := json.Marshal(locationMap)
-		fileMapFd := map[string]string{"map": string(fileMapJson)}
-
-		// Send request using resty
-		client := resty.New()
-		applySessionToRestyClient(client)
-		if len(apiKeyId) > 0 && len(apiKey) > 0 {
-			auth := base64.StdEncoding.EncodeToString([]byte(apiKeyId + ":" + apiKey))
-			client.SetHeader("Authorization", "Basic "+auth)
+// postAddArtifact sends addArtifactProgrammatic as a multipart request with
+// the files collected by processArtifactsInput.
+func postAddArtifact(variables map[string]interface{}, locationMap map[string][]string, filesMap map[string]interface{}) (*resty.Response, error) {
+	// Build GraphQL mutation
+	mutation := `
+		mutation AddArtifactProgrammatic($artifactInput: AddArtifactInput) {
+			addArtifactProgrammatic(artifactInput: $artifactInput) {
+				uuid
+				version
+				lifecycle
+				artifacts
+			}
 		}
+	`
 
-		c := client.R()
-		// Add files to multipart request
-		for key, value := range filesMap {
-			if fileData, ok := value.(FileData); ok {
-				c.SetFileReader(key, fileData.Filename, bytes.NewReader(fileData.Bytes))
-			}
+	// Execute GraphQL mutation
+	body := map[string]interface{}{
+		"query":     mutation,
+		"variables": variables,
+	}
+
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if debug == "true" {
+		fmt.Println("GraphQL Request:")
+		fmt.Println(string(jsonBody))
+	}
+
+	// Build GraphQL operation for multipart upload
+	od := make(map[string]interface{})
+	od["operationName"] = "AddArtifactProgrammatic"
+	od["variables"] = variables
+	od["query"] = mutation
+
+	jsonOd, _ := json.Marshal(od)
+	operations := map[string]string{"operations": string(jsonOd)}
+
+	// Build file map
+	fileMapJson, _ := json.Marshal(locationMap)
+	fileMapFd := map[string]string{"map": string(fileMapJson)}
+
+	// Send request using resty
+	client := resty.New()
+	applySessionToRestyClient(client)
+	if len(apiKeyId) > 0 && len(apiKey) > 0 {
+		auth := base64.StdEncoding.EncodeToString([]byte(apiKeyId + ":" + apiKey))
+		client.SetHeader("Authorization", "Basic "+auth)
+	}
+
+	c := client.R()
+	// Add files to multipart request
+	for key, value := range filesMap {
+		if fileData, ok := value.(FileData); ok {
+			c.SetFileReader(key, fileData.Filename, bytes.NewReader(fileData.Bytes))
 		}
+	}
 
-		resp, err := c.
-			SetHeader("Content-Type", "multipart/form-data").
-			SetHeader("User-Agent", "ReARM CLI").
-			SetHeader("Accept-Encoding", "gzip, deflate").
-			SetHeader("Apollo-Require-Preflight", "true").
-			SetMultipartFormData(operations).
-			SetMultipartFormData(fileMapFd).
-			SetBasicAuth(apiKeyId, apiKey).
-			Post(rearmUri + "/graphql")
+	resp, err := c.
+		SetHeader("Content-Type", "multipart/form-data").
+		SetHeader("User-Agent", "ReARM CLI").
+		SetHeader("Accept-Encoding", "gzip, deflate").
+		SetHeader("Apollo-Require-Preflight", "true").
+		SetMultipartFormData(operations).
+		SetMultipartFormData(fileMapFd).
+		SetBasicAuth(apiKeyId, apiKey).
+		Post(rearmUri + "/graphql")
+	auditResty(mutation, variables, resp, err)
+
+	return resp, err
+}
 
-		handleResponse(err, resp)
-	},
+// hasArtifactsToSend reports whether any release, deliverable or SCE artifact
+// is left in the input after already uploaded files were skipped.
+func hasArtifactsToSend(artifactInput map[string]interface{}) bool {
+	if arts, ok := artifactInput["releaseArtifacts"].(*[]Artifact); ok && len(*arts) > 0 {
+		return true
+	}
+	if groups, ok := artifactInput["deliverableArtifacts"].([]DeliverableArtifactGroup); ok {
+		for _, g := range groups {
+			if len(g.Artifacts) > 0 {
+				return true
+			}
+		}
+	}
+	if groups, ok := artifactInput["sceArtifacts"].([]SceArtifactGroup); ok {
+		for _, g := range groups {
+			if len(g.Artifacts) > 0 {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // processArtifactFiles processes artifact list and handles file uploads
@@ -253,7 +308,7 @@ func processArtifactFiles(artifacts *[]map[string]interface{}, filesCounter *int
 			fileContent, err := os.ReadFile(filePath)
 			if err != nil {
 				fmt.Printf("Error reading file %s: %v\n", filePath, err)
-				os.Exit(1)
+				exitCommand(1)
 			}
 
 			// Create file upload entry
@@ -286,4 +341,8 @@ func init() {
 	addArtifactCmd.Flags().StringVar(&addArtifactReleaseArts, "releasearts", "", "Release artifacts JSON array")
 	addArtifactCmd.Flags().StringVar(&addArtifactDeliverableArts, "deliverablearts", "", "Deliverable artifacts JSON array")
 	addArtifactCmd.Flags().StringVar(&addArtifactSceArts, "scearts", "", "SCE artifacts JSON array")
+	addArtifactCmd.Flags().BoolVar(&skipDigestRecords, "skip-digests", false, "(Optional) Do not compute and send sha256/sha512 digest records for uploaded artifact files")
+	addArtifactCmd.Flags().StringVar(&signingKeyPath, "signing-key", "", "(Optional) PEM ed25519/ECDSA or OpenSSH private key; uploaded files get a detached signature attached as a SIGNATURE artifact")
+	addArtifactCmd.Flags().StringVar(&signingKeyId, "key-id", "", "(Optional) Key id recorded on signatures (default: derived from the key)")
+	addArtifactCmd.Flags().BoolVar(&forceUpload, "force-upload", false, "Upload artifact files even if identical files are already stored on the target")
 }
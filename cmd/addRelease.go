@@ -71,7 +71,7 @@ func buildOutboundDeliverables(filesCounter *int, locationMap *map[string][]stri
 	// now do some length validations and add elements
 	if len(odelBuildId) > 0 && len(odelBuildId) != len(odelId) {
 		fmt.Println("number of --odelBuildId flags must be either zero or match number of --odelid flags")
-		os.Exit(2)
+		exitCommand(2)
 	} else if len(odelBuildId) > 0 {
 		for i, abid := range odelBuildId {
 			softwareMetadatas[i]["buildId"] = abid
@@ -80,7 +80,7 @@ func buildOutboundDeliverables(filesCounter *int, locationMap *map[string][]stri
 
 	if len(odelBuildUri) > 0 && len(odelBuildUri) != len(odelId) {
 		fmt.Println("number of --odelbuildUri flags must be either zero or match number of --odelid flags")
-		os.Exit(2)
+		exitCommand(2)
 	} else if len(odelBuildUri) > 0 {
 		for i, aburi := range odelBuildUri {
 			softwareMetadatas[i]["buildUri"] = aburi
@@ -89,7 +89,7 @@ func buildOutboundDeliverables(filesCounter *int, locationMap *map[string][]stri
 
 	if len(odelCiMeta) > 0 && len(odelCiMeta) != len(odelId) {
 		fmt.Println("number of --odelcimeta flags must be either zero or match number of --odelid flags")
-		os.Exit(2)
+		exitCommand(2)
 	} else if len(odelCiMeta) > 0 {
 		for i, acm := range odelCiMeta {
 			softwareMetadatas[i]["cicdMeta"] = acm
@@ -98,7 +98,7 @@ func buildOutboundDeliverables(filesCounter *int, locationMap *map[string][]stri
 
 	if len(odelDigests) > 0 && len(odelDigests) != len(odelId) {
 		fmt.Println("number of --odeldigests flags must be either zero or match number of --odelid flags")
-		os.Exit(2)
+		exitCommand(2)
 	} else if len(odelDigests) > 0 {
 		for i, ad := range odelDigests {
 			adSpl := strings.Split(ad, ",")
@@ -108,7 +108,7 @@ func buildOutboundDeliverables(filesCounter *int, locationMap *map[string][]stri
 
 	if len(dateStart) > 0 && len(dateStart) != len(odelId) {
 		fmt.Println("number of --datestart flags must be either zero or match number of --odelid flags")
-		os.Exit(2)
+		exitCommand(2)
 	} else if len(dateStart) > 0 {
 		for i, ds := range dateStart {
 			softwareMetadatas[i]["dateFrom"] = ds
@@ -117,7 +117,7 @@ func buildOutboundDeliverables(filesCounter *int, locationMap *map[string][]stri
 
 	if len(dateEnd) > 0 && len(dateEnd) != len(odelId) {
 		fmt.Println("number of --dateEnd flags must be either zero or match number of --odelid flags")
-		os.Exit(2)
+		exitCommand(2)
 	} else if len(dateEnd) > 0 {
 		for i, de := range dateEnd {
 			softwareMetadatas[i]["dateTo"] = de
@@ -126,7 +126,7 @@ func buildOutboundDeliverables(filesCounter *int, locationMap *map[string][]stri
 
 	if len(odelPackage) > 0 && len(odelPackage) != len(odelId) {
 		fmt.Println("number of --odelpackage flags must be either zero or match number of --odelid flags")
-		os.Exit(2)
+		exitCommand(2)
 	} else if len(odelPackage) > 0 {
 		for i, ap := range odelPackage {
 			softwareMetadatas[i]["packageType"] = strings.ToUpper(ap)
@@ -139,7 +139,7 @@ func buildOutboundDeliverables(filesCounter *int, locationMap *map[string][]stri
 
 	if len(odelType) > 0 && len(odelType) != len(odelId) {
 		fmt.Println("number of --odeltype flags must be either zero or match number of --odelid flags")
-		os.Exit(2)
+		exitCommand(2)
 	} else if len(odelType) > 0 {
 		for i, at := range odelType {
 			outboundDeliverables[i]["type"] = at
@@ -148,7 +148,7 @@ func buildOutboundDeliverables(filesCounter *int, locationMap *map[string][]stri
 
 	if len(supportedOsArr) > 0 && len(supportedOsArr) != len(odelId) {
 		fmt.Println("number of --osarr flags must be either zero or match number of --odelid flags")
-		os.Exit(2)
+		exitCommand(2)
 	} else if len(supportedOsArr) > 0 {
 		for i, ad := range supportedOsArr {
 			adSpl := strings.Split(ad, ",")
@@ -157,7 +157,7 @@ func buildOutboundDeliverables(filesCounter *int, locationMap *map[string][]stri
 	}
 	if len(supportedCpuArchArr) > 0 && len(supportedCpuArchArr) != len(odelId) {
 		fmt.Println("number of --supportedcpuarcharr flags must be either zero or match number of --odelid flags")
-		os.Exit(2)
+		exitCommand(2)
 	} else if len(supportedCpuArchArr) > 0 {
 		for i, ad := range supportedCpuArchArr {
 			adSpl := strings.Split(ad, ",")
@@ -167,7 +167,7 @@ func buildOutboundDeliverables(filesCounter *int, locationMap *map[string][]stri
 
 	if len(odelVersion) > 0 && len(odelVersion) != len(odelId) {
 		fmt.Println("number of --odelversion flags must be either zero or match number of --odelid flags")
-		os.Exit(2)
+		exitCommand(2)
 	} else if len(odelVersion) > 0 {
 		for i, av := range odelVersion {
 			outboundDeliverables[i]["version"] = av
@@ -176,7 +176,7 @@ func buildOutboundDeliverables(filesCounter *int, locationMap *map[string][]stri
 
 	if len(odelPublisher) > 0 && len(odelPublisher) != len(odelId) {
 		fmt.Println("number of --odelpublisher flags must be either zero or match number of --odelid flags")
-		os.Exit(2)
+		exitCommand(2)
 	} else if len(odelPublisher) > 0 {
 		for i, ap := range odelPublisher {
 			outboundDeliverables[i]["publisher"] = ap
@@ -185,7 +185,7 @@ func buildOutboundDeliverables(filesCounter *int, locationMap *map[string][]stri
 
 	if len(odelGroup) > 0 && len(odelGroup) != len(odelId) {
 		fmt.Println("number of --odelgroup flags must be either zero or match number of --odelid flags")
-		os.Exit(2)
+		exitCommand(2)
 	} else if len(odelGroup) > 0 {
 		for i, ag := range odelGroup {
 			outboundDeliverables[i]["group"] = ag
@@ -194,7 +194,7 @@ func buildOutboundDeliverables(filesCounter *int, locationMap *map[string][]stri
 
 	if len(tagsArr) > 0 && len(tagsArr) != len(odelId) {
 		fmt.Println("number of --tagsarr flags must be either zero or match number of --odelid flags")
-		os.Exit(2)
+		exitCommand(2)
 	} else if len(tagsArr) > 0 {
 		for i, tags := range tagsArr {
 			tagPairs := strings.Split(tags, ",")
@@ -203,7 +203,7 @@ func buildOutboundDeliverables(filesCounter *int, locationMap *map[string][]stri
 				keyValue := strings.Split(tagPair, ":")
 				if len(keyValue) != 2 {
 					fmt.Println("Each tag should have key and value")
-					os.Exit(2)
+					exitCommand(2)
 				}
 				tags = append(tags, TagInput{
 					Key:   keyValue[0],
@@ -216,7 +216,7 @@ func buildOutboundDeliverables(filesCounter *int, locationMap *map[string][]stri
 
 	if len(identifiers) > 0 && len(identifiers) != len(odelId) {
 		fmt.Println("number of --identifiers flags must be either zero or match number of --odelid flags")
-		os.Exit(2)
+		exitCommand(2)
 	} else if len(identifiers) > 0 {
 		for i, delIdentifiers := range identifiers {
 			identityPairs := strings.Split(delIdentifiers, ",")
@@ -225,7 +225,7 @@ func buildOutboundDeliverables(filesCounter *int, locationMap *map[string][]stri
 				keyValue := strings.SplitN(identityPair, ":", 2)
 				if len(keyValue) != 2 {
 					fmt.Println("Each tag should have key and value")
-					os.Exit(2)
+					exitCommand(2)
 				}
 				identifiers = append(identifiers, Identifier{
 					IdType:  keyValue[0],
@@ -237,15 +237,16 @@ func buildOutboundDeliverables(filesCounter *int, locationMap *map[string][]stri
 	}
 	if len(odelArtsJson) > 0 && len(odelArtsJson) != len(odelId) {
 		fmt.Println("number of --odelartsjson flags must be either zero or match number of --odelid flags")
-		os.Exit(2)
+		exitCommand(2)
 	} else if len(odelArtsJson) > 0 {
 		for i, artifactsInputString := range odelArtsJson {
 			var artifactsInput []Artifact
 			err := json.Unmarshal([]byte(artifactsInputString), &artifactsInput)
 			if err != nil {
 				fmt.Fprintln(os.Stderr, "Error parsing Artifact Input: ", err)
-				os.Exit(1)
+				exitCommand(1)
 			} else {
+				artifactsInput = skipUploadedArtifacts(artifactsInput, uploadTargetDeliverable+odelId[i])
 				indexPrefix := "variables.releaseInputProg.outboundDeliverables." + strconv.Itoa(i) + ".artifacts."
 				outboundDeliverables[i]["artifacts"] = *processArtifactsInput(&artifactsInput, indexPrefix, filesCounter, locationMap, filesMap)
 			}
@@ -278,6 +279,7 @@ func processArtifactsInput(artifactsInput *[]Artifact, indexPrefix string, files
 
 func processSingleArtifactInput(artInput *Artifact, indexPrefix string, fileJCounter int, filesCounter *int,
 	locationMap *map[string][]string, filesMap *map[string]interface{}) *Artifact {
+	withDetachedSignature(artInput)
 	// TODO: replace file path with actual file
 	if len((*artInput).Artifacts) > 0 {
 		updIndex := indexPrefix + strconv.Itoa(fileJCounter) + ".artifacts."
@@ -286,13 +288,14 @@ func processSingleArtifactInput(artInput *Artifact, indexPrefix string, fileJCou
 	// File path is required for artifacts
 	if (*artInput).FilePath == "" {
 		fmt.Fprintln(os.Stderr, "Error: filePath is required for each artifact")
-		os.Exit(1)
+		exitCommand(1)
 	}
 	fileBytes, err := os.ReadFile(artInput.FilePath)
 	if err != nil {
 		fmt.Println("Error reading file: ", err)
-		os.Exit(1)
+		exitCommand(1)
 	}
+	removeStagedSignature(artInput.FilePath)
 	*filesCounter++
 	currentIndex := strconv.Itoa(*filesCounter)
 
@@ -304,6 +307,7 @@ func processSingleArtifactInput(artInput *Artifact, indexPrefix string, fileJCou
 	artInput.File = nil
 	(*artInput).FilePath = ""
 	(*artInput).StripBom = strings.ToUpper(stripBom)
+	(*artInput).DigestRecords = withComputedDigests((*artInput).DigestRecords, fileBytes)
 	return artInput
 }
 
@@ -321,8 +325,9 @@ func buildCommitMap(filesCounter *int, locationMap *map[string][]string, filesMa
 		err := json.Unmarshal([]byte(sceArts), &sceArtifacts)
 		if err != nil {
 			fmt.Fprintln(os.Stderr, "Error parsing Artifact Input: ", err)
-			os.Exit(1)
+			exitCommand(1)
 		} else {
+			sceArtifacts = skipUploadedArtifacts(sceArtifacts, uploadTargetSce)
 			indexPrefix := "variables.releaseInputProg.sourceCodeEntry.artifacts."
 			artifactsObject := *processArtifactsInput(&sceArtifacts, indexPrefix, filesCounter, locationMap, filesMap)
 			commitObj.Artifacts = artifactsObject
@@ -335,7 +340,7 @@ func buildCommitsInBody() *[]Commit {
 	plainCommits, err := base64.StdEncoding.DecodeString(commits)
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 	indCommits := strings.Split(string(plainCommits), "\n")
 	commitsInBody := make([]Commit, len(indCommits)-1)
@@ -362,8 +367,9 @@ func buildReleaseArts(filesCounter *int, locationMap *map[string][]string, files
 	err := json.Unmarshal([]byte(releaseArts), &releaseArtifacts)
 	if err != nil {
 		fmt.Println("Error parsing Release Artifact Input: ", err)
-		os.Exit(1)
+		exitCommand(1)
 	} else {
+		releaseArtifacts = skipUploadedArtifacts(releaseArtifacts, uploadTargetRelease)
 		indexPrefix := "variables.releaseInputProg.artifacts."
 		artifactsObject = *processArtifactsInput(&releaseArtifacts, indexPrefix, filesCounter, locationMap, filesMap)
 	}
@@ -381,11 +387,25 @@ var addreleaseCmd = &cobra.Command{
 			fmt.Println("Using ReARM at", rearmUri)
 		}
 
+		applyCachedComponent()
 		resolveCommitsInput()
+		// On CI reruns skip files that are already stored on the release.
+		if rebuildRelease {
+			prepareRebuildArtifactIndex()
+		}
 		locationMap := make(map[string][]string)
 		filesMap := make(map[string]interface{})
 		filesCounter := 0
 
+		if uploadConcurrency < 0 || uploadRetries < 0 {
+			fmt.Fprintln(os.Stderr, "Error: --upload-concurrency and --upload-retries must not be negative")
+			exitCommand(2)
+		}
+		var deferredUploads []*deferredUpload
+		if uploadConcurrency > 0 {
+			deferredUploads = prepareDeferredUploads()
+		}
+
 		body := map[string]interface{}{"branch": branch, "version": version}
 		if len(lifecycle) > 0 {
 			body["lifecycle"] = strings.ToUpper(lifecycle)
@@ -481,46 +501,68 @@ var addreleaseCmd = &cobra.Command{
 			fmt.Println(string(jsonBody))
 		}
 
-		od := make(map[string]interface{})
-		od["operationName"] = "addReleaseProgrammatic"
-		od["variables"] = map[string]interface{}{"releaseInputProg": body}
-		od["query"] = `mutation addReleaseProgrammatic($releaseInputProg: ReleaseInputProg!) {addReleaseProgrammatic(release:$releaseInputProg) {` + RELEASE_GQL_DATA + `}}`
-
-		jsonOd, _ := json.Marshal(od)
-		operations := map[string]string{"operations": string(jsonOd)}
+		finalLifecycle := ""
+		if uploadConcurrency > 0 {
+			finalLifecycle = deferReleaseLifecycle(body)
+		}
 
-		fileMapJson, _ := json.Marshal(locationMap)
-		fileMapFd := map[string]string{"map": string(fileMapJson)}
-		// write a wrapper to send the gql upload request via post form data
-		client := resty.New()
-		applySessionToRestyClient(client)
-		if len(apiKeyId) > 0 && len(apiKey) > 0 {
-			auth := base64.StdEncoding.EncodeToString([]byte(apiKeyId + ":" + apiKey))
-			client.SetHeader("Authorization", "Basic "+auth)
+		resp, err := postAddRelease(body, locationMap, filesMap)
+		if _, gqlErr := graphQLResponseData(resp, err); cachedComponentRejected(gqlErr, func() { delete(body, "component") }) {
+			resp, err = postAddRelease(body, locationMap, filesMap)
 		}
-		c := client.R()
-		for key, value := range filesMap {
-			if fileData, ok := value.(FileData); ok {
-				c.SetFileReader(key, fileData.Filename, bytes.NewReader(fileData.Bytes))
-			} else {
-				// Handle error case: value is not FileData
-				fmt.Printf("Warning: Value for key '%s' is not FileData\n", key)
-			}
+		printSkippedUploads()
+		if uploadConcurrency > 0 {
+			finishDeferredUploads(resp, err, deferredUploads, finalLifecycle)
+			return
 		}
-
-		resp, err := c.SetHeader("Content-Type", "multipart/form-data").
-			SetHeader("User-Agent", "ReARM CLI").
-			SetHeader("Accept-Encoding", "gzip, deflate").
-			SetHeader("Apollo-Require-Preflight", "true").
-			SetMultipartFormData(operations).
-			SetMultipartFormData(fileMapFd).
-			SetBasicAuth(apiKeyId, apiKey).
-			Post(rearmUri + "/graphql")
-
 		handleResponse(err, resp)
 	},
 }
 
+// postAddRelease sends addReleaseProgrammatic as a multipart request with the
+// files collected by processArtifactsInput.
+func postAddRelease(body map[string]interface{}, locationMap map[string][]string, filesMap map[string]interface{}) (*resty.Response, error) {
+	variables := map[string]interface{}{"releaseInputProg": body}
+	query := `mutation addReleaseProgrammatic($releaseInputProg: ReleaseInputProg!) {addReleaseProgrammatic(release:$releaseInputProg) {` + RELEASE_GQL_DATA + `}}`
+	od := make(map[string]interface{})
+	od["operationName"] = "addReleaseProgrammatic"
+	od["variables"] = variables
+	od["query"] = query
+
+	jsonOd, _ := json.Marshal(od)
+	operations := map[string]string{"operations": string(jsonOd)}
+
+	fileMapJson, _ := json.Marshal(locationMap)
+	fileMapFd := map[string]string{"map": string(fileMapJson)}
+	// write a wrapper to send the gql upload request via post form data
+	client := resty.New()
+	applySessionToRestyClient(client)
+	if len(apiKeyId) > 0 && len(apiKey) > 0 {
+		auth := base64.StdEncoding.EncodeToString([]byte(apiKeyId + ":" + apiKey))
+		client.SetHeader("Authorization", "Basic "+auth)
+	}
+	c := client.R()
+	for key, value := range filesMap {
+		if fileData, ok := value.(FileData); ok {
+			c.SetFileReader(key, fileData.Filename, bytes.NewReader(fileData.Bytes))
+		} else {
+			// Handle error case: value is not FileData
+			fmt.Printf("Warning: Value for key '%s' is not FileData\n", key)
+		}
+	}
+
+	resp, err := c.SetHeader("Content-Type", "multipart/form-data").
+		SetHeader("User-Agent", "ReARM CLI").
+		SetHeader("Accept-Encoding", "gzip, deflate").
+		SetHeader("Apollo-Require-Preflight", "true").
+		SetMultipartFormData(operations).
+		SetMultipartFormData(fileMapFd).
+		SetBasicAuth(apiKeyId, apiKey).
+		Post(rearmUri + "/graphql")
+	auditResty(query, variables, resp, err)
+	return resp, err
+}
+
 func init() {
 	addreleaseCmd.PersistentFlags().StringVarP(&branch, "branch", "b", "", "Name of VCS Branch used")
 	addreleaseCmd.PersistentFlags().StringVarP(&version, "version", "v", "", "Release version")
@@ -556,6 +598,8 @@ func init() {
 	addreleaseCmd.PersistentFlags().StringArrayVar(&supportedOsArr, "osarr", []string{}, "Deliverable supported OS array (multiple allowed, use comma seprated values for each deliverable)")
 	addreleaseCmd.PersistentFlags().StringArrayVar(&supportedCpuArchArr, "cpuarr", []string{}, "Deliverable supported CPU array (multiple allowed, use comma seprated values for each deliverable)")
 	addreleaseCmd.PersistentFlags().StringArrayVar(&odelArtsJson, "odelartsjson", []string{}, "Deliverable Artifacts json array (multiple allowed, use a json array for each deliverable)")
+	addreleaseCmd.PersistentFlags().IntVar(&uploadConcurrency, "upload-concurrency", 0, "Create the release first, then upload its artifacts with this many parallel addArtifactProgrammatic requests (optional, 0 sends everything in one request)")
+	addreleaseCmd.PersistentFlags().IntVar(&uploadRetries, "upload-retries", 3, "Number of retries for each artifact upload that fails with a network error or a 5xx response when --upload-concurrency is set")
 	addreleaseCmd.PersistentFlags().StringVar(&releaseArts, "releasearts", "", "Release Artifacts json array")
 	addreleaseCmd.PersistentFlags().StringVar(&sceArts, "scearts", "", "Source Code Entry Artifacts json array")
 	addreleaseCmd.PersistentFlags().StringVar(&lifecycle, "lifecycle", "DRAFT", "Lifecycle of release - set to 'REJECTED' for failed releases, otherwise 'DRAFT' or 'ASSEMBLED' are possible options (optional, default value is 'DRAFT').")
@@ -566,6 +610,10 @@ func init() {
 	addreleaseCmd.PersistentFlags().StringVar(&createComponentName, "createcomponent-name", "", "(Optional) Display name for new component. Only used with --createcomponent. Requires organization-wide read-write API key.")
 	addreleaseCmd.PersistentFlags().StringVar(&perspective, "perspective", "", "(Optional) Perspective UUID. When supplied together with --createcomponent and the component does not yet exist, the new component is assigned to this perspective. Requires a FREEFORM API key with WRITE permission on the perspective. Ignored when the component already exists.")
 	addreleaseCmd.PersistentFlags().BoolVar(&rebuildRelease, "rebuild", false, "(Optional) Allow rebuilding release on repeated CI reruns. Default is false.")
+	addreleaseCmd.PersistentFlags().BoolVar(&skipDigestRecords, "skip-digests", false, "(Optional) Do not compute and send sha256/sha512 digest records for uploaded artifact files")
+	addreleaseCmd.PersistentFlags().StringVar(&signingKeyPath, "signing-key", "", "(Optional) PEM ed25519/ECDSA or OpenSSH private key; uploaded files get a detached signature attached as a SIGNATURE artifact")
+	addreleaseCmd.PersistentFlags().StringVar(&signingKeyId, "key-id", "", "(Optional) Key id recorded on signatures (default: derived from the key)")
+	addreleaseCmd.PersistentFlags().BoolVar(&forceUpload, "force-upload", false, "(Optional) With --rebuild, upload artifact files even if identical files are already stored on the release.")
 	// PR-data flags. When run from CI on a PR build, the workflow can
 	// populate these from the SCM event so a first-class PullRequest
 	// entity is upserted (keyed by target VCS + identity) and its head
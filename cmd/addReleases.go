@@ -87,7 +87,7 @@ Example batch.json (one element shown):
 		releases := readBatchReleasesFromFile(batchInfile)
 		if len(releases) == 0 {
 			fmt.Fprintln(os.Stderr, "Error: --infile must contain a non-empty JSON array of releases")
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		locationMap := make(map[string][]string)
@@ -105,10 +105,12 @@ Example batch.json (one element shown):
 			fmt.Println(string(jsonReleases))
 		}
 
+		variables := map[string]interface{}{"releaseInputsProg": releases}
+		query := `mutation addReleasesProgrammatic($releaseInputsProg: [ReleaseInputProg!]!) {addReleasesProgrammatic(releases:$releaseInputsProg) {` + RELEASE_GQL_DATA + `}}`
 		od := make(map[string]interface{})
 		od["operationName"] = "addReleasesProgrammatic"
-		od["variables"] = map[string]interface{}{"releaseInputsProg": releases}
-		od["query"] = `mutation addReleasesProgrammatic($releaseInputsProg: [ReleaseInputProg!]!) {addReleasesProgrammatic(releases:$releaseInputsProg) {` + RELEASE_GQL_DATA + `}}`
+		od["variables"] = variables
+		od["query"] = query
 
 		jsonOd, _ := json.Marshal(od)
 		operations := map[string]string{"operations": string(jsonOd)}
@@ -139,6 +141,7 @@ Example batch.json (one element shown):
 			SetMultipartFormData(fileMapFd).
 			SetBasicAuth(apiKeyId, apiKey).
 			Post(rearmUri + "/graphql")
+		auditResty(query, variables, resp, err)
 
 		handleResponse(err, resp)
 	},
@@ -148,17 +151,17 @@ Example batch.json (one element shown):
 func readBatchReleasesFromFile(filePath string) []map[string]interface{} {
 	if filePath == "" {
 		fmt.Fprintln(os.Stderr, "Error: --infile is required")
-		os.Exit(1)
+		exitCommand(1)
 	}
 	raw, err := os.ReadFile(filePath)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error reading batch file: ", err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 	var releases []map[string]interface{}
 	if err := json.Unmarshal(raw, &releases); err != nil {
 		fmt.Fprintln(os.Stderr, "Error parsing batch file (expected a JSON array of release objects): ", err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 	return releases
 }
@@ -218,12 +221,12 @@ func processArtifactsAtKey(container map[string]interface{}, key, indexPrefix st
 	bts, err := json.Marshal(raw)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error reading artifacts: ", err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 	var arts []Artifact
 	if err := json.Unmarshal(bts, &arts); err != nil {
 		fmt.Fprintln(os.Stderr, "Error parsing artifacts: ", err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 	if len(arts) == 0 {
 		return
@@ -236,5 +239,8 @@ func init() {
 	addReleasesCmd.PersistentFlags().StringVar(&batchInfile, "infile", "", "Path to a JSON file with an array of release objects (ReleaseInputProg shape). Artifacts reference local files via their filePath field.")
 	addReleasesCmd.MarkPersistentFlagRequired("infile")
 	addReleasesCmd.PersistentFlags().StringVar(&stripBom, "stripbom", "true", "(Optional) Set --stripbom false to disable striping bom for digest matching. Applied to every artifact in the batch.")
+	addReleasesCmd.PersistentFlags().BoolVar(&skipDigestRecords, "skip-digests", false, "(Optional) Do not compute and send sha256/sha512 digest records for uploaded artifact files")
+	addReleasesCmd.PersistentFlags().StringVar(&signingKeyPath, "signing-key", "", "(Optional) PEM ed25519/ECDSA or OpenSSH private key; uploaded files get a detached signature attached as a SIGNATURE artifact")
+	addReleasesCmd.PersistentFlags().StringVar(&signingKeyId, "key-id", "", "(Optional) Key id recorded on signatures (default: derived from the key)")
 	rootCmd.AddCommand(addReleasesCmd)
 }
@@ -127,7 +127,7 @@ idempotent — the existing session is returned.`,
 		data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
 		if err != nil {
 			printGqlError(err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 		emitJson(data["sessionInitializeProgrammatic"])
 	},
@@ -151,7 +151,7 @@ var agentSessionTouchCmd = &cobra.Command{
 		data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
 		if err != nil {
 			printGqlError(err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 		emitJson(data["sessionTouchProgrammatic"])
 	},
@@ -175,7 +175,7 @@ var agentSessionCloseCmd = &cobra.Command{
 		data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
 		if err != nil {
 			printGqlError(err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 		emitJson(data["sessionCloseProgrammatic"])
 	},
@@ -213,7 +213,7 @@ the current full state.`,
 		data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
 		if err != nil {
 			printGqlError(err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 		emitJson(data["sessionProgrammatic"])
 	},
@@ -269,7 +269,7 @@ permission on its component/product.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if releaseShowSessionUuid == "" && releaseShowClientSessionId == "" {
 			fmt.Fprintln(os.Stderr, "--session or --client-session-id is required")
-			os.Exit(1)
+			exitCommand(1)
 		}
 		// Per-artifact metrics fragment — each artifact (BOM, SARIF /
 		// CODE_SCANNING_RESULT, VDR, …) carries its own scan metrics, so a
@@ -328,7 +328,7 @@ permission on its component/product.`,
 		data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
 		if err != nil {
 			printGqlError(err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 		emitJson(data["agenticReleaseProgrammatic"])
 	},
@@ -389,7 +389,7 @@ recommended cadence. See $REARM_URL/api/agents/orientation.md.`,
 		data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
 		if err != nil {
 			printGqlError(err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 		emitJson(data["agentSessionInboxProgrammatic"])
 	},
@@ -428,16 +428,16 @@ CEL session.* policy surface.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if addArtifactFile == "" {
 			fmt.Fprintln(os.Stderr, "--file is required")
-			os.Exit(1)
+			exitCommand(1)
 		}
 		if addArtifactType == "" {
 			fmt.Fprintln(os.Stderr, "--type is required (e.g. AGENTIC_REPORT)")
-			os.Exit(1)
+			exitCommand(1)
 		}
 		fileBytes, err := os.ReadFile(addArtifactFile)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to read --file %s: %v\n", addArtifactFile, err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 		fileName := filepath.Base(addArtifactFile)
 
@@ -447,7 +447,7 @@ CEL session.* policy surface.`,
 			eq := strings.Index(t, "=")
 			if eq <= 0 {
 				fmt.Fprintf(os.Stderr, "Invalid --tag %q — expected key=value\n", t)
-				os.Exit(1)
+				exitCommand(1)
 			}
 			tags = append(tags, map[string]interface{}{
 				"key":   t[:eq],
@@ -470,8 +470,8 @@ CEL session.* policy surface.`,
 		if len(tags) > 0 {
 			art["tags"] = tags
 		}
-		if len(addArtifactDigests) > 0 {
-			art["digestRecords"] = addArtifactDigests
+		if digests := withComputedDigests(addArtifactDigests, fileBytes); len(digests) > 0 {
+			art["digestRecords"] = digests
 		}
 
 		mutation := `
@@ -532,6 +532,7 @@ CEL session.* policy surface.`,
 			}).
 			SetBasicAuth(apiKeyId, apiKey).
 			Post(rearmUri + "/graphql")
+		auditResty(mutation, variables, resp, err)
 		handleResponse(err, resp)
 	},
 }
@@ -554,7 +555,8 @@ func init() {
 	agentSessionAddArtifactCmd.PersistentFlags().StringVar(&addArtifactType, "type", "", "ArtifactType enum (e.g. AGENTIC_REPORT) — required")
 	agentSessionAddArtifactCmd.PersistentFlags().StringVar(&addArtifactDisplayId, "display-id", "", "Display identifier; defaults to the file basename")
 	agentSessionAddArtifactCmd.PersistentFlags().StringSliceVar(&addArtifactTags, "tag", nil, "Tag in key=value form — repeatable (e.g. --tag agenticPhase=ORIENTATION)")
-	agentSessionAddArtifactCmd.PersistentFlags().StringSliceVar(&addArtifactDigests, "digest", nil, "Pre-computed digest record(s) — optional, server auto-computes when omitted")
+	agentSessionAddArtifactCmd.PersistentFlags().StringSliceVar(&addArtifactDigests, "digest", nil, "Pre-computed digest record(s) — optional, sent in addition to the sha256/sha512 records the CLI computes")
+	agentSessionAddArtifactCmd.PersistentFlags().BoolVar(&skipDigestRecords, "skip-digests", false, "(Optional) Do not compute and send sha256/sha512 digest records for uploaded artifact files")
 	_ = agentSessionAddArtifactCmd.MarkPersistentFlagRequired("file")
 	_ = agentSessionAddArtifactCmd.MarkPersistentFlagRequired("type")
 
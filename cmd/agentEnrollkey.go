@@ -51,12 +51,12 @@ allowed_signers principal the verifier will check against
 	Run: func(cmd *cobra.Command, args []string) {
 		if enrollAgentUuid == "" || enrollKeyFormat == "" || enrollPubkeyFile == "" {
 			fmt.Fprintln(os.Stderr, "--agent, --format, and --pubkey-file are required")
-			os.Exit(1)
+			exitCommand(1)
 		}
 		pubKey, err := os.ReadFile(enrollPubkeyFile)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to read pubkey file: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 		runEnrollkey(enrollAgentUuid, enrollKeyFormat,
 			enrollPubkeyFile, string(pubKey), enrollFingerprint, enrollKeyIdentity)
@@ -69,7 +69,7 @@ func runEnrollkey(ownerUuid, format, pubkeyFile, pubKey, fingerprint, identity s
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Could not derive fingerprint locally: %v\n", err)
 			fmt.Fprintln(os.Stderr, "Pass --fingerprint explicitly or install ssh-keygen / gpg.")
-			os.Exit(1)
+			exitCommand(1)
 		}
 		fingerprint = fp
 	}
@@ -108,7 +108,7 @@ func runEnrollkey(ownerUuid, format, pubkeyFile, pubKey, fingerprint, identity s
 	data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
 	if err != nil {
 		printGqlError(err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 	emitJson(data[op])
 }
@@ -20,14 +20,20 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	approvalEntry string
-	approvalRole  string
-	approvalState string
+	approvalEntry []string
+	approvalRole  []string
+	approvalState []string
+	approvalsFile string
+	// approvalSummary prints the --approvals-file summary for flag-based
+	// approvals too, instead of the approved release.
+	approvalSummary bool
 )
 
 type Approval struct {
@@ -36,30 +42,83 @@ type Approval struct {
 	State          string `json:"state"`
 }
 
+// ReleaseApprovals is one element of the --approvals-file array: the target
+// release (by UUID, or by component and version) and the approvals to submit
+// on it. Mirrors ReleaseApprovalProgrammaticInput.
+type ReleaseApprovals struct {
+	Release   string     `json:"release,omitempty"`
+	Component string     `json:"component,omitempty"`
+	Version   string     `json:"version,omitempty"`
+	Approvals []Approval `json:"approvals"`
+}
+
+// ApprovalResult is one line of the --approvals-file summary report.
+type ApprovalResult struct {
+	Release   string     `json:"release,omitempty"`
+	Component string     `json:"component,omitempty"`
+	Version   string     `json:"version,omitempty"`
+	Approvals []Approval `json:"approvals"`
+	Status    string     `json:"status"`
+	Error     string     `json:"error,omitempty"`
+}
+
+const approveReleaseQuery = `
+	mutation approveReleaseProgrammatic($releaseApprovals: ReleaseApprovalProgrammaticInput!) {
+		approveReleaseProgrammatic(releaseApprovals:$releaseApprovals) {` + RELEASE_GQL_DATA + `}
+	}
+`
+
 var approveReleaseCmd = &cobra.Command{
 	Use:   "approverelease",
 	Short: "Programmatic approval of releases using valid API key",
 	Long: `This CLI command would connect to ReARM and submit approval for a release using valid API key.
 			The API key used must be valid and also must be authorized
-			to perform requested approval.`,
+			to perform requested approval.
+
+			Several approvals can be submitted on one release by repeating the
+			--approvalentry, --approvalrole and --approvalstate flags (the n-th
+			occurrence of each forms one approval).
+
+			Approvals for many releases can be submitted at once with --approvals-file,
+			pointing at a JSON array of objects shaped like
+			{"release": "<uuid>", "approvals": [{"approvalEntry": "<uuid>", "approvalRoleId": "<role>", "state": "APPROVED"}]}
+			(or "component" and "version" instead of "release"). Each release is
+			approved separately.
+
+			With --approvals-file, or with --summary, a summary of applied and
+			refused approvals is printed instead of the approved release, and the
+			command exits with 1 if any were refused.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if debug == "true" {
 			fmt.Println("Using ReARM instance at", rearmUri)
 		}
 
-		body := map[string]interface{}{}
-		approval := Approval{ApprovalEntry: approvalEntry, ApprovalRoleId: approvalRole, State: approvalState}
-		approvals := make([]Approval, 1)
-		approvals[0] = approval
-		body["approvals"] = approvals
-		if len(releaseId) > 0 {
-			body["release"] = releaseId
+		if approvalsFile != "" {
+			if len(approvalEntry) > 0 || len(approvalRole) > 0 || len(approvalState) > 0 {
+				fmt.Println("Error: --approvals-file and --approvalentry/--approvalrole/--approvalstate are mutually exclusive")
+				exitCommand(1)
+			}
+			approveReleasesFromFile()
+			return
+		}
+
+		if len(approvalEntry) == 0 {
+			fmt.Println("Error: either --approvals-file or --approvalentry, --approvalrole and --approvalstate must be set")
+			exitCommand(1)
+		}
+		if len(approvalEntry) != len(approvalRole) || len(approvalEntry) != len(approvalState) {
+			fmt.Println("Error: number of --approvalentry, --approvalrole and --approvalstate flags must be the same")
+			exitCommand(1)
 		}
-		if len(releaseVersion) > 0 {
-			body["version"] = releaseVersion
+		approvals := make([]Approval, len(approvalEntry))
+		for i := range approvalEntry {
+			approvals[i] = Approval{ApprovalEntry: approvalEntry[i], ApprovalRoleId: approvalRole[i], State: strings.ToUpper(approvalState[i])}
 		}
-		if len(component) > 0 {
-			body["component"] = component
+		body := ReleaseApprovals{
+			Release:   releaseId,
+			Version:   releaseVersion,
+			Component: component,
+			Approvals: approvals,
 		}
 
 		if debug == "true" {
@@ -67,25 +126,91 @@ var approveReleaseCmd = &cobra.Command{
 			fmt.Println("Request body = ", string(jsonBody))
 		}
 
-		query := `
-			mutation approveReleaseProgrammatic($releaseApprovals: ReleaseApprovalProgrammaticInput!) {
-				approveReleaseProgrammatic(releaseApprovals:$releaseApprovals) {` + RELEASE_GQL_DATA + `}
-			}
-		`
+		if approvalSummary {
+			approveReleases([]ReleaseApprovals{body})
+			return
+		}
 		variables := map[string]interface{}{"releaseApprovals": body}
-		fmt.Println(sendRequest(query, variables, "approveReleaseProgrammatic"))
+		fmt.Println(sendRequest(approveReleaseQuery, variables, "approveReleaseProgrammatic"))
 	},
 }
 
+// approveReleasesFromFile reads --approvals-file and submits its entries.
+func approveReleasesFromFile() {
+	raw, err := os.ReadFile(approvalsFile)
+	if err != nil {
+		fmt.Println("Error reading approvals file:", err)
+		exitCommand(1)
+	}
+	var entries []ReleaseApprovals
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		fmt.Println("Error parsing approvals file (expected a JSON array of release approvals):", err)
+		exitCommand(1)
+	}
+	if len(entries) == 0 {
+		fmt.Println("Error: approvals file contains no entries")
+		exitCommand(1)
+	}
+	approveReleases(entries)
+}
+
+// approveReleases submits the approvals of every entry in turn, carrying on
+// past refusals, and prints the summary report.
+func approveReleases(entries []ReleaseApprovals) {
+	results := make([]ApprovalResult, 0, len(entries))
+	refused := 0
+	for _, entry := range entries {
+		for i := range entry.Approvals {
+			entry.Approvals[i].State = strings.ToUpper(entry.Approvals[i].State)
+		}
+		result := ApprovalResult{
+			Release:   entry.Release,
+			Component: entry.Component,
+			Version:   entry.Version,
+			Approvals: entry.Approvals,
+			Status:    "APPLIED",
+		}
+		if entry.Release == "" && (entry.Component == "" || entry.Version == "") {
+			result.Status = "REFUSED"
+			result.Error = "either release or component and version must be set"
+		} else if len(entry.Approvals) == 0 {
+			result.Status = "REFUSED"
+			result.Error = "no approvals listed"
+		} else {
+			variables := map[string]interface{}{"releaseApprovals": entry}
+			data, err := sendGraphQLRequest(approveReleaseQuery, variables, rearmUri+"/graphql")
+			if err != nil {
+				result.Status = "REFUSED"
+				result.Error = err.Error()
+			} else if rlz, ok := data["approveReleaseProgrammatic"].(map[string]interface{}); ok {
+				// Fill in the release UUID when the entry addressed it by version.
+				if uuid, ok := rlz["uuid"].(string); ok {
+					result.Release = uuid
+				}
+			}
+		}
+		if result.Status == "REFUSED" {
+			refused++
+		}
+		results = append(results, result)
+	}
+
+	out, _ := json.Marshal(results)
+	fmt.Println(string(out))
+	if refused > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d release approvals were refused\n", refused, len(results))
+		exitCommand(1)
+	}
+}
+
 func init() {
 	approveReleaseCmd.PersistentFlags().StringVar(&releaseId, "releaseid", "", "UUID of release to be approved (either releaseid or releaseversion and component must be set)")
 	approveReleaseCmd.PersistentFlags().StringVar(&releaseVersion, "releaseversion", "", "Version of release to be approved (either releaseid or releaseversion and component must be set)")
 	approveReleaseCmd.PersistentFlags().StringVar(&component, "component", "", "UUID of component or product which release should be approved (either releaseid or releaseversion and component must be set)")
-	approveReleaseCmd.PersistentFlags().StringVar(&approvalEntry, "approvalentry", "", "UUID of approval to approve")
-	approveReleaseCmd.PersistentFlags().StringVar(&approvalRole, "approvalrole", "", "Approval role with which to approve")
-	approveReleaseCmd.PersistentFlags().StringVar(&approvalState, "approvalstate", "", "Approval state, possible values: APPROVED, DISAPPROVED")
-	approveReleaseCmd.MarkPersistentFlagRequired("approvalentry")
-	approveReleaseCmd.MarkPersistentFlagRequired("approvalrole")
-	approveReleaseCmd.MarkPersistentFlagRequired("approvalstate")
+	approveReleaseCmd.PersistentFlags().StringArrayVar(&approvalEntry, "approvalentry", []string{}, "UUID of approval to approve (multiple allowed, each must be matched by --approvalrole and --approvalstate)")
+	approveReleaseCmd.PersistentFlags().StringArrayVar(&approvalRole, "approvalrole", []string{}, "Approval role with which to approve (multiple allowed)")
+	approveReleaseCmd.PersistentFlags().StringArrayVar(&approvalState, "approvalstate", []string{}, "Approval state, possible values: APPROVED, DISAPPROVED (multiple allowed)")
+	approveReleaseCmd.PersistentFlags().StringVar(&approvalsFile, "approvals-file", "", "Path to a JSON file listing approvals for many releases (mutually exclusive with --approvalentry, --approvalrole and --approvalstate)")
+	approveReleaseCmd.PersistentFlags().BoolVar(&approvalSummary, "summary", false, "Print the APPLIED/REFUSED summary used by --approvals-file instead of the approved release (optional)")
 	rootCmd.AddCommand(approveReleaseCmd)
 }
@@ -0,0 +1,208 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// forceUpload disables the skip-already-uploaded check on addrelease
+// --rebuild and addartifact.
+var forceUpload bool
+
+// Upload targets used to key existing artifacts.
+const (
+	uploadTargetRelease     = "RELEASE"
+	uploadTargetSce         = "SCE"
+	uploadTargetDeliverable = "DELIVERABLE:"
+)
+
+type skippedArtifact struct {
+	FilePath string
+	Target   string
+	Existing string
+}
+
+// uploadedArtifacts indexes the sha256 digests of the artifacts already stored
+// on a release, per target. It is nil when the check is not active, in which
+// case every artifact is uploaded.
+var uploadedArtifacts map[string]map[string]string
+var skippedUploads []skippedArtifact
+
+// prepareUploadedArtifactIndex loads the artifacts already attached to the
+// release so that identical files can be skipped. Failures are not fatal: the
+// CLI falls back to uploading everything.
+func prepareUploadedArtifactIndex(releaseUuid string) {
+	if forceUpload || releaseUuid == "" {
+		return
+	}
+	var rlz releaseContent
+	if err := fetchReleaseContent(releaseUuid, "", &rlz); err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: could not load the artifacts already on the release, all files will be uploaded:", err)
+		return
+	}
+	indexUploadedArtifacts(&rlz)
+}
+
+// prepareRebuildArtifactIndex loads the artifacts of the release addrelease
+// --rebuild is about to rebuild. Without --component the release is looked up
+// by branch and version through --vcsuri or the component of the API key.
+func prepareRebuildArtifactIndex() {
+	if forceUpload {
+		return
+	}
+	if component != "" {
+		releaseUuid, err := resolveReleaseUuidByVersion(component, version)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Warning: could not look up the release being rebuilt, all files will be uploaded again:", err)
+			return
+		}
+		prepareUploadedArtifactIndex(releaseUuid)
+		return
+	}
+	input := map[string]interface{}{"branch": branch, "upToVersion": version}
+	if vcsUri != "" {
+		input["vcsUri"] = vcsUri
+		if repoPath != "" {
+			input["repoPath"] = repoPath
+		}
+	}
+	rlz, err := resolveLatestRelease(input)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: could not look up the release being rebuilt, all files will be uploaded again:", err)
+		return
+	}
+	if rlz == nil || rlz.Version != version {
+		// first run for this version, nothing stored yet
+		return
+	}
+	indexUploadedArtifacts(rlz)
+}
+
+func indexUploadedArtifacts(rlz *releaseContent) {
+	uploadedArtifacts = uploadedArtifactIndex(rlz)
+}
+
+// uploadedArtifactIndex maps the sha256 digests of the artifacts on rlz to
+// their UUIDs, per upload target.
+func uploadedArtifactIndex(rlz *releaseContent) map[string]map[string]string {
+	index := make(map[string]map[string]string)
+	add := func(target string, arts []releaseArtifact) {
+		for _, a := range arts {
+			for _, dr := range a.DigestRecords {
+				if d := normalizeSha256(dr.Algo, dr.Digest); d != "" {
+					if index[target] == nil {
+						index[target] = make(map[string]string)
+					}
+					index[target][d] = a.Uuid
+				}
+			}
+		}
+	}
+	add(uploadTargetRelease, rlz.ArtifactDetails)
+	if rlz.SourceCodeEntryDetails != nil {
+		add(uploadTargetSce, rlz.SourceCodeEntryDetails.ArtifactDetails)
+		add(uploadTargetSce+":"+rlz.SourceCodeEntryDetails.Uuid, rlz.SourceCodeEntryDetails.ArtifactDetails)
+	}
+	for _, d := range rlz.deliverables() {
+		add(uploadTargetDeliverable+d.Uuid, d.ArtifactDetails)
+		add(uploadTargetDeliverable+d.DisplayIdentifier, d.ArtifactDetails)
+	}
+	return index
+}
+
+// resolveReleaseUuidByVersion looks up the UUID of an existing release of a
+// component. Returns an empty string and no error when the release does not
+// exist; transport, authorization and decoding failures are returned.
+func resolveReleaseUuidByVersion(componentUuid string, releaseVersion string) (string, error) {
+	if componentUuid == "" || releaseVersion == "" {
+		return "", nil
+	}
+	query := `
+		query ($version: String!, $componentId: ID!) {
+			getReleaseByReleaseVersionProgrammatic(version: $version, componentId: $componentId)
+		}
+	`
+	data, err := sendGraphQLRequest(query, map[string]interface{}{"version": releaseVersion, "componentId": componentUuid}, rearmUri+"/graphql")
+	if err != nil {
+		return "", err
+	}
+	raw, ok := data["getReleaseByReleaseVersionProgrammatic"].(string)
+	if !ok || raw == "" || raw == "null" {
+		return "", nil
+	}
+	var rlz struct {
+		Uuid string `json:"uuid"`
+	}
+	if err := json.Unmarshal([]byte(raw), &rlz); err != nil {
+		return "", fmt.Errorf("parsing release %s of component %s: %w", releaseVersion, componentUuid, err)
+	}
+	return rlz.Uuid, nil
+}
+
+// skipUploadedArtifacts drops artifacts whose file content is already stored
+// on the given target, recording them for printSkippedUploads. An artifact
+// with nested artifacts is only dropped when all of them are stored too, so
+// nested artifacts such as detached signatures are never lost.
+func skipUploadedArtifacts(arts []Artifact, target string) []Artifact {
+	kept := []Artifact{}
+	for _, a := range arts {
+		if uuid := uploadedArtifactUuid(a, target); uuid != "" && nestedArtifactsUploaded(a.Artifacts, target) {
+			skippedUploads = append(skippedUploads, skippedArtifact{FilePath: a.FilePath, Target: target, Existing: uuid})
+			continue
+		}
+		kept = append(kept, a)
+	}
+	return kept
+}
+
+func nestedArtifactsUploaded(arts []Artifact, target string) bool {
+	for _, a := range arts {
+		if uploadedArtifactUuid(a, target) == "" || !nestedArtifactsUploaded(a.Artifacts, target) {
+			return false
+		}
+	}
+	return true
+}
+
+// uploadedArtifactUuid returns the UUID of the artifact already stored on
+// target with the same file content as a, or an empty string.
+func uploadedArtifactUuid(a Artifact, target string) string {
+	existing := uploadedArtifacts[target]
+	if len(existing) == 0 || a.FilePath == "" {
+		return ""
+	}
+	d, err := fileSha256(a.FilePath)
+	if err != nil {
+		return ""
+	}
+	return existing[d]
+}
+
+func printSkippedUploads() {
+	if len(skippedUploads) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Skipped %d already uploaded file(s), use --force-upload to upload them again:\n", len(skippedUploads))
+	for _, s := range skippedUploads {
+		fmt.Fprintf(os.Stderr, "  %s (%s, existing artifact %s)\n", s.FilePath, strings.TrimSuffix(s.Target, ":"), s.Existing)
+	}
+}
@@ -0,0 +1,260 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// auditLogPath is the JSONL file mutating requests are appended to. Empty
+// disables the audit log.
+var auditLogPath string
+
+// auditCommand is the command path of the running command, looked up from
+// the arguments in Execute and captured again in the root PersistentPreRun so
+// records can name the CLI invocation.
+var auditCommand string
+
+const auditRedacted = "[REDACTED]"
+
+var (
+	auditMutationPattern = regexp.MustCompile(`^\s*mutation\b[^{]*\{\s*(\w+)`)
+	auditUuidPattern     = regexp.MustCompile(`(?i)^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+)
+
+// auditSensitiveKeys are lower-cased substrings of variable names whose
+// values are never written to the audit log.
+var auditSensitiveKeys = []string{"secret", "password", "passwd", "token", "apikey", "privatekey", "cert", "credential"}
+
+// auditExitOperation is the operation of the record written when the CLI
+// exits.
+const auditExitOperation = "exit"
+
+// AuditRecord is one line of the audit log. For requests Status is the
+// outcome of the single request, "ok" or "error". Every invocation ends with
+// an "exit" record carrying the exit status of the process.
+type AuditRecord struct {
+	Timestamp  string      `json:"timestamp"`
+	Command    string      `json:"command,omitempty"`
+	Operation  string      `json:"operation"`
+	Variables  interface{} `json:"variables,omitempty"`
+	Targets    []string    `json:"targets,omitempty"`
+	Results    []string    `json:"results,omitempty"`
+	Status     string      `json:"status"`
+	Error      string      `json:"error,omitempty"`
+	ExitStatus *int        `json:"exitStatus,omitempty"`
+}
+
+// auditGraphQL records a GraphQL request in the audit log when it is a
+// mutation. Queries are not recorded.
+func auditGraphQL(query string, variables map[string]interface{}, data map[string]interface{}, err error) {
+	if auditLogPath == "" {
+		return
+	}
+	operation := AuditOperation(query)
+	if operation == "" {
+		return
+	}
+	recordAudit(operation, variables, data, err)
+}
+
+// AuditOperation returns the name of the first field of a GraphQL mutation,
+// or an empty string when query is not a mutation.
+func AuditOperation(query string) string {
+	m := auditMutationPattern.FindStringSubmatch(query)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// auditResty records a mutation sent outside sendGraphQLRequest, such as a
+// multipart upload, from its raw response.
+func auditResty(query string, variables map[string]interface{}, resp *resty.Response, err error) {
+	if auditLogPath == "" {
+		return
+	}
+	data, err := graphQLResponseData(resp, err)
+	auditGraphQL(query, variables, data, err)
+}
+
+// recordAudit appends one record for operation to the audit log. Problems
+// writing the log are reported on stderr but do not fail the command, since
+// the mutation has already been applied.
+func recordAudit(operation string, variables map[string]interface{}, data map[string]interface{}, reqErr error) {
+	if auditLogPath == "" {
+		return
+	}
+	var vars interface{}
+	if variables != nil {
+		decodeInto(variables, &vars)
+	}
+	rec := AuditRecord{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Command:   auditCommand,
+		Operation: operation,
+		Variables: RedactAuditValue(vars),
+		Targets:   collectAuditUuids(vars, false),
+		Status:    "ok",
+	}
+	if reqErr != nil {
+		rec.Status = "error"
+		rec.Error = reqErr.Error()
+	} else if data != nil {
+		var out interface{}
+		decodeInto(data, &out)
+		rec.Results = collectAuditUuids(out, true)
+	}
+	if err := appendAuditRecord(rec); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not write audit log %s: %v\n", auditLogPath, err)
+	}
+}
+
+// NewAuditExitRecord returns the record written when command exits with
+// code.
+func NewAuditExitRecord(command string, code int) AuditRecord {
+	rec := AuditRecord{
+		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+		Command:    command,
+		Operation:  auditExitOperation,
+		Status:     "ok",
+		ExitStatus: &code,
+	}
+	if code != 0 {
+		rec.Status = "error"
+	}
+	return rec
+}
+
+// recordAuditExit appends the exit record of the invocation to the audit
+// log.
+func recordAuditExit(code int) {
+	if auditLogPath == "" {
+		return
+	}
+	if err := appendAuditRecord(NewAuditExitRecord(auditCommand, code)); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not write audit log %s: %v\n", auditLogPath, err)
+	}
+}
+
+// exitCommand records the exit status in the audit log and ends the process.
+// Commands call it instead of os.Exit.
+func exitCommand(code int) {
+	recordAuditExit(code)
+	os.Exit(code)
+}
+
+func appendAuditRecord(rec AuditRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(auditLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// RedactAuditValue returns a copy of value with the values of sensitive keys
+// replaced.
+func RedactAuditValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			if isSensitiveAuditKey(k) && val != nil {
+				out[k] = auditRedacted
+			} else {
+				out[k] = RedactAuditValue(val)
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			out[i] = RedactAuditValue(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func isSensitiveAuditKey(key string) bool {
+	k := strings.ToLower(key)
+	for _, s := range auditSensitiveKeys {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectAuditUuids returns the distinct UUIDs found in value, sorted. With
+// uuidKeysOnly set only values of "uuid" keys are collected, which picks out
+// the entities a mutation returned rather than every reference they carry.
+func collectAuditUuids(value interface{}, uuidKeysOnly bool) []string {
+	seen := make(map[string]bool)
+	var walk func(key string, v interface{})
+	walk = func(key string, v interface{}) {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			for k, val := range t {
+				walk(k, val)
+			}
+		case []interface{}:
+			for _, val := range t {
+				walk(key, val)
+			}
+		case string:
+			if uuidKeysOnly && key != "uuid" {
+				return
+			}
+			if isSensitiveAuditKey(key) {
+				return
+			}
+			if auditUuidPattern.MatchString(t) {
+				seen[strings.ToLower(t)] = true
+			}
+		}
+	}
+	walk("", value)
+	var out []string
+	for u := range seen {
+		out = append(out, u)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&auditLogPath, "audit-log", "", "Append a JSONL record of every mutating request and of the exit status to this file (also settable as audit-log in the config file)")
+}
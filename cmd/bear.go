@@ -23,7 +23,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"strings"
 	"time"
 
@@ -173,7 +172,7 @@ func enrichSupplierFunc() {
 	enrichedComponents, err := bearEnrichBatch(purlsToEnrich)
 	if err != nil {
 		fmt.Printf("Error during enrichment: %v\n", err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	// Update components with enriched supplier data
@@ -198,7 +197,7 @@ func enrichSupplierFunc() {
 	outErr := writeOutput(bom)
 	if outErr != nil {
 		fmt.Println(outErr)
-		os.Exit(1)
+		exitCommand(1)
 	}
 }
 
@@ -235,7 +234,7 @@ func enrichLicenseFunc() {
 	enrichedComponents, err := bearEnrichBatch(purlsToEnrich)
 	if err != nil {
 		fmt.Printf("Error during enrichment: %v\n", err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	// Update components with enriched license data
@@ -260,7 +259,7 @@ func enrichLicenseFunc() {
 	outErr := writeOutput(bom)
 	if outErr != nil {
 		fmt.Println(outErr)
-		os.Exit(1)
+		exitCommand(1)
 	}
 }
 
@@ -327,7 +326,7 @@ func enrichFunc() {
 	enrichedComponents, err := bearEnrichBatch(purlsToEnrich)
 	if err != nil {
 		fmt.Printf("Error during enrichment: %v\n", err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	// Update components with enriched data
@@ -372,7 +371,7 @@ func enrichFunc() {
 	outErr := writeOutput(bom)
 	if outErr != nil {
 		fmt.Println(outErr)
-		os.Exit(1)
+		exitCommand(1)
 	}
 }
 
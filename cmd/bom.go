@@ -69,23 +69,31 @@ func readBom() *cdx.BOM {
 	jsonData, err := readJSON()
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	return readBomFromBytes(jsonData)
 }
 
 func readBomFromBytes(data []byte) *cdx.BOM {
-	bom := new(cdx.BOM)
-	decoder := cdx.NewBOMDecoder(bytes.NewReader(data), cdx.BOMFileFormatJSON)
-	err := decoder.Decode(bom)
+	bom, err := parseBom(data)
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 	return bom
 }
 
+// parseBom decodes a CycloneDX JSON BOM.
+func parseBom(data []byte) (*cdx.BOM, error) {
+	bom := new(cdx.BOM)
+	decoder := cdx.NewBOMDecoder(bytes.NewReader(data), cdx.BOMFileFormatJSON)
+	if err := decoder.Decode(bom); err != nil {
+		return nil, err
+	}
+	return bom, nil
+}
+
 func writeOutput(bom *cdx.BOM) error {
 	buf := new(bytes.Buffer)
 	err := cdx.NewBOMEncoder(buf, cdx.BOMFileFormatJSON).
@@ -127,7 +135,7 @@ func fixPurlFunc() {
 
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	bom := readBomFromBytes(data)
@@ -140,7 +148,7 @@ func fixPurlFunc() {
 		_, err := packageurl.FromString(newpurl)
 		if err != nil {
 			fmt.Println(err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		newPurl = newpurl
@@ -166,7 +174,7 @@ func fixPurlFunc() {
 	err = writeOutput(bom)
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 }
 func replaceStringInJSONBytes(data []byte, old string, new string) []byte {
@@ -0,0 +1,717 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const bundleFormatVersion = 1
+
+// Fixed entries of an evidence bundle; artifact files live under
+// bundleArtifactsDir in the layout of release download.
+const (
+	bundleManifestFile  = "manifest.json"
+	bundleSignatureFile = "manifest.json.sig"
+	bundleReleaseFile   = "release.json"
+	bundleArtifactsDir  = "artifacts"
+)
+
+var (
+	bundleRelease         string
+	bundleOutfile         string
+	bundleConcurrency     int
+	bundleFile            string
+	bundleComponent       string
+	bundleBranch          string
+	bundleVersion         string
+	bundleLifecycle       string
+	bundleCreateComponent bool
+)
+
+// BUNDLE_RELEASE_GQL_DATA extends RELEASE_CONTENT_GQL_DATA with the metadata
+// needed to recreate the release, its source code entry and its deliverables
+// on another ReARM instance.
+const BUNDLE_RELEASE_GQL_DATA = RELEASE_CONTENT_GQL_DATA + `
+	branchDetails {
+		uuid
+		name
+	}
+	sourceCodeEntryDetails {
+		commitAuthor
+		commitEmail
+		vcsRepository {
+			uri
+			type
+		}
+		artifactDetails {
+			version
+			tags {
+				key
+				value
+			}
+		}
+	}
+	variantDetails {
+		outboundDeliverableDetails {
+			type
+			group
+			publisher
+			tags {
+				key
+				value
+			}
+			identities {
+				identityType
+				identity
+			}
+			supportedOs
+			supportedCpuArchitectures
+			softwareMetadata {
+				buildId
+				buildUri
+				cicdMeta
+				packageType
+			}
+			artifactDetails {
+				version
+				tags {
+					key
+					value
+				}
+			}
+		}
+	}
+`
+
+// BundleManifest is written as manifest.json into an evidence bundle. Files
+// lists every other entry of the bundle except the manifest signature.
+type BundleManifest struct {
+	BundleVersion int              `json:"bundleVersion"`
+	Created       string           `json:"created"`
+	Source        string           `json:"source"`
+	Release       BundleReleaseRef `json:"release"`
+	Files         []BundleFile     `json:"files"`
+	Artifacts     []BundleArtifact `json:"artifacts"`
+	Signature     *BundleSignature `json:"signature,omitempty"`
+}
+
+type BundleReleaseRef struct {
+	ReleaseRef
+	ComponentUuid string `json:"componentUuid"`
+	Branch        string `json:"branch"`
+}
+
+type BundleFile struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	Sha256 string `json:"sha256"`
+}
+
+// BundleArtifact is a downloaded artifact together with the metadata needed
+// to upload it again.
+type BundleArtifact struct {
+	DownloadedArtifact
+	DeliverableUuid string         `json:"deliverableUuid,omitempty"`
+	BomFormat       string         `json:"bomFormat,omitempty"`
+	Version         string         `json:"version,omitempty"`
+	Tags            []TagRecord    `json:"tags,omitempty"`
+	DigestRecords   []DigestRecord `json:"digestRecords,omitempty"`
+}
+
+type BundleSignature struct {
+	File   string `json:"file"`
+	Format string `json:"format"`
+	KeyId  string `json:"keyId,omitempty"`
+}
+
+// bundleReleaseMeta is the part of release.json used to recreate the release
+// beyond what releaseContent covers.
+type bundleReleaseMeta struct {
+	Lifecycle     string `json:"lifecycle"`
+	Endpoint      string `json:"endpoint"`
+	BranchDetails *struct {
+		Name string `json:"name"`
+	} `json:"branchDetails"`
+	SourceCodeEntryDetails *struct {
+		Commit        string `json:"commit"`
+		CommitMessage string `json:"commitMessage"`
+		CommitAuthor  string `json:"commitAuthor"`
+		CommitEmail   string `json:"commitEmail"`
+		VcsTag        string `json:"vcsTag"`
+		DateActual    string `json:"dateActual"`
+		VcsRepository *struct {
+			Uri  string `json:"uri"`
+			Type string `json:"type"`
+		} `json:"vcsRepository"`
+	} `json:"sourceCodeEntryDetails"`
+	VcsRepository *struct {
+		Uri  string `json:"uri"`
+		Type string `json:"type"`
+	} `json:"vcsRepository"`
+	VariantDetails []struct {
+		OutboundDeliverableDetails []map[string]interface{} `json:"outboundDeliverableDetails"`
+	} `json:"variantDetails"`
+}
+
+// Deliverable fields that DeliverableInput and SoftwareMetadataInput accept
+// unchanged from the exported release.
+var (
+	bundleDeliverableFields      = []string{"displayIdentifier", "identities", "type", "tags", "version", "publisher", "group", "supportedOs", "supportedCpuArchitectures"}
+	bundleSoftwareMetadataFields = []string{"buildId", "buildUri", "cicdMeta", "digests", "packageType"}
+)
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Export and import release evidence bundles",
+	Long: `Set of commands to move a release with all of its artifacts between ReARM instances,
+for example into an air-gapped environment, as a single tarball.`,
+}
+
+var bundleExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a release with all of its artifacts into a bundle",
+	Long: `Writes a gzipped tarball containing
+
+  manifest.json      release reference, artifact metadata and the sha256 of every file
+  manifest.json.sig  detached signature of manifest.json (with --signing-key)
+  release.json       release metadata as returned by ReARM
+  artifacts/         every artifact of the release, its source code entry and its
+                     deliverables, in the layout of release download
+
+Artifacts are downloaded as uploaded and checked against their recorded digests; the
+export fails if any download fails or any digest does not match.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if bundleConcurrency < 1 {
+			fmt.Fprintln(os.Stderr, "Error: --concurrency must be at least 1")
+			exitCommand(2)
+		}
+		if debug == "true" {
+			fmt.Fprintln(os.Stderr, "Using ReARM at", rearmUri)
+		}
+		if err := exportBundle(bundleRelease, bundleOutfile); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			exitCommand(1)
+		}
+		fmt.Println(bundleOutfile)
+	},
+}
+
+var bundleImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Recreate an exported release with its artifacts on this ReARM instance",
+	Long: `Checks the bundle files against the manifest, then creates the release with
+addReleaseProgrammatic, uploading every artifact of the bundle to the release, its
+source code entry and its deliverables.
+
+The release is created on --component, or on the component matching the VCS repository
+recorded in the bundle. With --createcomponent a missing component is created with the
+name from the bundle. Branch, version and lifecycle are taken from the bundle unless
+overridden; lifecycles past ASSEMBLED are imported as ASSEMBLED.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM at", rearmUri)
+		}
+		dir, manifest, err := ExtractBundle(bundleFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error reading bundle:", err)
+			exitCommand(1)
+		}
+		if problems := CheckBundleFiles(dir, manifest); len(problems) > 0 {
+			os.RemoveAll(dir)
+			fmt.Fprintln(os.Stderr, "Error: bundle does not match its manifest:")
+			for _, p := range problems {
+				fmt.Fprintln(os.Stderr, "  "+p)
+			}
+			exitCommand(1)
+		}
+		locationMap := make(map[string][]string)
+		filesMap := make(map[string]interface{})
+		body, err := buildBundleReleaseInput(dir, manifest, locationMap, filesMap)
+		// file contents are in filesMap now
+		os.RemoveAll(dir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			exitCommand(2)
+		}
+		if debug == "true" {
+			jsonBody, _ := json.Marshal(body)
+			fmt.Println(string(jsonBody))
+		}
+		resp, err := postAddRelease(body, locationMap, filesMap)
+		handleResponse(err, resp)
+	},
+}
+
+// exportBundle downloads the release into a staging directory and packs it
+// into a bundle at outfile.
+func exportBundle(releaseUuid string, outfile string) error {
+	rlzRaw, err := fetchRelease(releaseUuid, BUNDLE_RELEASE_GQL_DATA)
+	if err != nil {
+		return err
+	}
+	var rlz releaseContent
+	var meta bundleReleaseMeta
+	if err := decodeInto(rlzRaw, &rlz); err != nil {
+		return err
+	}
+	if err := decodeInto(rlzRaw, &meta); err != nil {
+		return err
+	}
+
+	staging, err := os.MkdirTemp("", "rearm-bundle")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+
+	releaseJson, _ := json.MarshalIndent(rlzRaw, "", "  ")
+	if err := os.WriteFile(filepath.Join(staging, bundleReleaseFile), releaseJson, 0644); err != nil {
+		return err
+	}
+
+	arts := rlz.allArtifacts()
+	fmt.Fprintf(os.Stderr, "Exporting %d artifact(s) of %s %s\n", len(arts), rlz.ComponentDetails.Name, rlz.Version)
+	downloaded := downloadReleaseArtifacts(arts, filepath.Join(staging, bundleArtifactsDir), true, bundleConcurrency)
+
+	deliverableOf := make(map[string]string)
+	for _, d := range rlz.deliverables() {
+		for _, a := range d.ArtifactDetails {
+			deliverableOf[a.Uuid] = d.Uuid
+		}
+	}
+	manifest := BundleManifest{
+		BundleVersion: bundleFormatVersion,
+		Created:       time.Now().UTC().Format(time.RFC3339),
+		Source:        rearmUri,
+		Release: BundleReleaseRef{
+			ReleaseRef: ReleaseRef{
+				Uuid:      rlz.Uuid,
+				Component: rlz.ComponentDetails.Name,
+				Version:   rlz.Version,
+				Lifecycle: rlz.Lifecycle,
+			},
+			ComponentUuid: rlz.ComponentDetails.Uuid,
+		},
+		Files:     []BundleFile{},
+		Artifacts: []BundleArtifact{},
+	}
+	if meta.BranchDetails != nil {
+		manifest.Release.Branch = meta.BranchDetails.Name
+	}
+	var failed []string
+	for i, d := range downloaded {
+		if d.Verification == downloadFailed || d.Verification == downloadMismatch {
+			failed = append(failed, d.Uuid+": "+d.Error)
+			continue
+		}
+		d.Path = path.Join(bundleArtifactsDir, d.Path)
+		manifest.Artifacts = append(manifest.Artifacts, BundleArtifact{
+			DownloadedArtifact: d,
+			DeliverableUuid:    deliverableOf[d.Uuid],
+			BomFormat:          arts[i].BomFormat,
+			Version:            arts[i].Version,
+			Tags:               arts[i].Tags,
+			DigestRecords:      arts[i].DigestRecords,
+		})
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d artifact(s) failed download or verification:\n  %s", len(failed), strings.Join(failed, "\n  "))
+	}
+
+	if manifest.Files, err = bundleFileList(staging); err != nil {
+		return err
+	}
+	signer, err := loadArtifactSigner()
+	if err != nil {
+		return fmt.Errorf("loading signing key: %w", err)
+	}
+	if signer != nil {
+		manifest.Signature = &BundleSignature{File: bundleSignatureFile, Format: signer.format, KeyId: signer.keyId}
+	}
+	manifestJson, _ := json.MarshalIndent(manifest, "", "  ")
+	manifestPath := filepath.Join(staging, bundleManifestFile)
+	if err := os.WriteFile(manifestPath, append(manifestJson, '\n'), 0644); err != nil {
+		return err
+	}
+	if signer != nil {
+		sigPath, err := signer.signFile(manifestPath)
+		if err != nil {
+			return fmt.Errorf("signing manifest: %w", err)
+		}
+		sig, err := os.ReadFile(sigPath)
+		if err != nil {
+			return err
+		}
+		removeStagedSignature(sigPath)
+		if err := os.WriteFile(filepath.Join(staging, bundleSignatureFile), sig, 0644); err != nil {
+			return err
+		}
+	}
+	return writeBundleTarball(staging, outfile)
+}
+
+// bundleFileList hashes every file below dir.
+func bundleFileList(dir string) ([]BundleFile, error) {
+	files := []BundleFile{}
+	err := filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		h, err := hashFile(p)
+		if err != nil {
+			return err
+		}
+		files = append(files, BundleFile{Path: filepath.ToSlash(rel), Size: h.Size, Sha256: h.Sha256})
+		return nil
+	})
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, err
+}
+
+// writeBundleTarball packs dir into a gzipped tarball with the manifest and
+// its signature first.
+func writeBundleTarball(dir string, outfile string) error {
+	files, err := bundleFileList(dir)
+	if err != nil {
+		return err
+	}
+	order := func(p string) int {
+		switch p {
+		case bundleManifestFile:
+			return 0
+		case bundleSignatureFile:
+			return 1
+		}
+		return 2
+	}
+	sort.SliceStable(files, func(i, j int) bool { return order(files[i].Path) < order(files[j].Path) })
+
+	out, err := os.Create(outfile)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+	now := time.Now().UTC()
+	for _, f := range files {
+		hdr := &tar.Header{Name: f.Path, Mode: 0644, Size: f.Size, ModTime: now, Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		src, err := os.Open(filepath.Join(dir, filepath.FromSlash(f.Path)))
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(tw, src)
+		src.Close()
+		if err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// ExtractBundle unpacks a bundle into a new temporary directory and reads
+// its manifest. The caller removes the directory.
+func ExtractBundle(bundlePath string) (string, *BundleManifest, error) {
+	f, err := os.Open(bundlePath)
+	if err != nil {
+		return "", nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return "", nil, err
+	}
+	dir, err := os.MkdirTemp("", "rearm-bundle")
+	if err != nil {
+		return "", nil, err
+	}
+	fail := func(err error) (string, *BundleManifest, error) {
+		os.RemoveAll(dir)
+		return "", nil, err
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fail(err)
+		}
+		if hdr.Typeflag == tar.TypeDir {
+			continue
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			return fail(fmt.Errorf("unexpected entry %s of type %c", hdr.Name, hdr.Typeflag))
+		}
+		name := path.Clean(hdr.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fail(fmt.Errorf("entry %s points outside of the bundle", hdr.Name))
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fail(err)
+		}
+		dst, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0644)
+		if err != nil {
+			return fail(err)
+		}
+		_, err = io.Copy(dst, tr)
+		dst.Close()
+		if err != nil {
+			return fail(err)
+		}
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, bundleManifestFile))
+	if err != nil {
+		return fail(fmt.Errorf("bundle has no %s", bundleManifestFile))
+	}
+	var manifest BundleManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return fail(fmt.Errorf("parsing %s: %w", bundleManifestFile, err))
+	}
+	if manifest.BundleVersion > bundleFormatVersion {
+		return fail(fmt.Errorf("bundle version %d is newer than supported version %d", manifest.BundleVersion, bundleFormatVersion))
+	}
+	return dir, &manifest, nil
+}
+
+// CheckBundleFiles compares the extracted files against the manifest and
+// returns a description of every difference.
+func CheckBundleFiles(dir string, manifest *BundleManifest) []string {
+	var problems []string
+	actual, err := bundleFileList(dir)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	present := make(map[string]BundleFile)
+	for _, f := range actual {
+		present[f.Path] = f
+	}
+	listed := map[string]bool{bundleManifestFile: true}
+	if manifest.Signature != nil {
+		listed[manifest.Signature.File] = true
+	}
+	for _, f := range manifest.Files {
+		listed[f.Path] = true
+		a, ok := present[f.Path]
+		if !ok {
+			problems = append(problems, f.Path+": missing")
+		} else if a.Sha256 != f.Sha256 || a.Size != f.Size {
+			problems = append(problems, f.Path+": sha256 mismatch")
+		}
+	}
+	for _, a := range actual {
+		if !listed[a.Path] {
+			problems = append(problems, a.Path+": not listed in the manifest")
+		}
+	}
+	return problems
+}
+
+// buildBundleReleaseInput builds the ReleaseInputProg for an extracted
+// bundle and registers its artifact files for the multipart upload.
+func buildBundleReleaseInput(dir string, manifest *BundleManifest, locationMap map[string][]string, filesMap map[string]interface{}) (map[string]interface{}, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, bundleReleaseFile))
+	if err != nil {
+		return nil, err
+	}
+	var meta bundleReleaseMeta
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", bundleReleaseFile, err)
+	}
+
+	body := map[string]interface{}{
+		"branch":  manifest.Release.Branch,
+		"version": manifest.Release.Version,
+	}
+	if bundleBranch != "" {
+		body["branch"] = bundleBranch
+	}
+	if bundleVersion != "" {
+		body["version"] = bundleVersion
+	}
+	if body["branch"] == "" {
+		return nil, fmt.Errorf("the bundle has no branch, specify --branch")
+	}
+	lc := strings.ToUpper(bundleLifecycle)
+	if lc == "" {
+		switch manifest.Release.Lifecycle {
+		case "PENDING", "DRAFT", "REJECTED", "CANCELLED":
+			lc = "DRAFT"
+		default:
+			lc = "ASSEMBLED"
+		}
+	}
+	body["lifecycle"] = lc
+	if meta.Endpoint != "" {
+		body["endpoint"] = meta.Endpoint
+	}
+
+	var vcs *struct {
+		Uri  string `json:"uri"`
+		Type string `json:"type"`
+	}
+	if meta.SourceCodeEntryDetails != nil && meta.SourceCodeEntryDetails.VcsRepository != nil {
+		vcs = meta.SourceCodeEntryDetails.VcsRepository
+	} else if meta.VcsRepository != nil {
+		vcs = meta.VcsRepository
+	}
+	if bundleComponent != "" {
+		body["component"] = bundleComponent
+	} else if vcs != nil && vcs.Uri != "" {
+		body["vcsUri"] = vcs.Uri
+	} else {
+		return nil, fmt.Errorf("the bundle has no VCS repository to identify the component, specify --component")
+	}
+	if bundleCreateComponent {
+		body["createComponentIfMissing"] = true
+		body["createComponentName"] = manifest.Release.Component
+	}
+
+	artifactsOf := func(belongsTo string, deliverable string) []Artifact {
+		var out []Artifact
+		for _, a := range manifest.Artifacts {
+			if a.BelongsTo != belongsTo || a.DeliverableUuid != deliverable {
+				continue
+			}
+			art := Artifact{
+				DisplayIdentifier: a.DisplayIdentifier,
+				Version:           a.Version,
+				Type:              a.Type,
+				BomFormat:         a.BomFormat,
+				StoredIn:          "REARM",
+				FilePath:          filepath.Join(dir, filepath.FromSlash(a.Path)),
+			}
+			for _, t := range a.Tags {
+				art.Tags = append(art.Tags, TagInput{Key: t.Key, Value: t.Value})
+			}
+			out = append(out, art)
+		}
+		return out
+	}
+	filesCounter := 0
+
+	if arts := artifactsOf(artifactOwnerRelease, ""); len(arts) > 0 {
+		body["artifacts"] = *processArtifactsInput(&arts, "variables.releaseInputProg.artifacts.", &filesCounter, &locationMap, &filesMap)
+	}
+
+	if sce := meta.SourceCodeEntryDetails; sce != nil && sce.Commit != "" {
+		entry := Commit{
+			Commit:        sce.Commit,
+			CommitMessage: sce.CommitMessage,
+			CommitAuthor:  sce.CommitAuthor,
+			CommitEmail:   sce.CommitEmail,
+			VcsTag:        sce.VcsTag,
+			DateActual:    sce.DateActual,
+		}
+		if vcs != nil {
+			entry.Uri = vcs.Uri
+			entry.Type = vcs.Type
+		}
+		if arts := artifactsOf(artifactOwnerSce, ""); len(arts) > 0 {
+			entry.Artifacts = *processArtifactsInput(&arts, "variables.releaseInputProg.sourceCodeEntry.artifacts.", &filesCounter, &locationMap, &filesMap)
+		}
+		body["sourceCodeEntry"] = entry
+	}
+
+	var deliverables []map[string]interface{}
+	seen := make(map[string]bool)
+	for _, v := range meta.VariantDetails {
+		for _, d := range v.OutboundDeliverableDetails {
+			uuid, _ := d["uuid"].(string)
+			if seen[uuid] {
+				continue
+			}
+			seen[uuid] = true
+			input := map[string]interface{}{}
+			for _, k := range bundleDeliverableFields {
+				if d[k] != nil {
+					input[k] = d[k]
+				}
+			}
+			if sm, ok := d["softwareMetadata"].(map[string]interface{}); ok {
+				metadata := map[string]interface{}{}
+				for _, k := range bundleSoftwareMetadataFields {
+					if sm[k] != nil {
+						metadata[k] = sm[k]
+					}
+				}
+				input["softwareMetadata"] = metadata
+			}
+			if arts := artifactsOf(artifactOwnerDeliverable, uuid); len(arts) > 0 {
+				prefix := "variables.releaseInputProg.outboundDeliverables." + strconv.Itoa(len(deliverables)) + ".artifacts."
+				input["artifacts"] = *processArtifactsInput(&arts, prefix, &filesCounter, &locationMap, &filesMap)
+			}
+			deliverables = append(deliverables, input)
+		}
+	}
+	if len(deliverables) > 0 {
+		body["outboundDeliverables"] = deliverables
+	}
+	return body, nil
+}
+
+func init() {
+	bundleExportCmd.PersistentFlags().StringVar(&bundleRelease, "release", "", "UUID of the release to export")
+	bundleExportCmd.PersistentFlags().StringVar(&bundleOutfile, "outfile", "", "Path of the bundle to write (.tar.gz)")
+	bundleExportCmd.PersistentFlags().IntVar(&bundleConcurrency, "concurrency", 4, "Number of parallel downloads")
+	bundleExportCmd.PersistentFlags().StringVar(&signingKeyPath, "signing-key", "", "(Optional) PEM ed25519/ECDSA or OpenSSH private key to sign the manifest with")
+	bundleExportCmd.PersistentFlags().StringVar(&signingKeyId, "key-id", "", "(Optional) Key id recorded in the manifest (default: derived from the key)")
+	bundleExportCmd.MarkPersistentFlagRequired("release")
+	bundleExportCmd.MarkPersistentFlagRequired("outfile")
+
+	bundleImportCmd.PersistentFlags().StringVar(&bundleFile, "bundle", "", "Path of the bundle to import")
+	bundleImportCmd.PersistentFlags().StringVar(&bundleComponent, "component", "", "Component UUID to create the release on (default: component matching the VCS repository of the bundle)")
+	bundleImportCmd.PersistentFlags().StringVarP(&bundleBranch, "branch", "b", "", "Branch name (default: branch of the exported release)")
+	bundleImportCmd.PersistentFlags().StringVarP(&bundleVersion, "version", "v", "", "Release version (default: version of the exported release)")
+	bundleImportCmd.PersistentFlags().StringVar(&bundleLifecycle, "lifecycle", "", "Lifecycle of the release, DRAFT or ASSEMBLED (default: derived from the exported release)")
+	bundleImportCmd.PersistentFlags().BoolVar(&bundleCreateComponent, "createcomponent", false, "(Optional) Create the component if it doesn't exist. Requires organization-wide read-write API key.")
+	bundleImportCmd.MarkPersistentFlagRequired("bundle")
+
+	bundleCmd.AddCommand(bundleExportCmd)
+	bundleCmd.AddCommand(bundleImportCmd)
+	rootCmd.AddCommand(bundleCmd)
+}
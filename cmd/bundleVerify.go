@@ -0,0 +1,326 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spf13/cobra"
+)
+
+var (
+	bundleVerifyPubkeyFile string
+	bundleVerifyFormat     string
+)
+
+// bomDigestPattern finds digests embedded in purls and bom-refs, such as
+// pkg:oci/app@sha256%3A<hex>.
+var bomDigestPattern = regexp.MustCompile(`(?i)(sha256|sha512)(?::|%3A)([0-9a-f]{64,128})`)
+
+// BundleVerification is the machine-readable report of bundle verify.
+type BundleVerification struct {
+	Verdict string        `json:"verdict"`
+	Bundle  string        `json:"bundle"`
+	Release ReleaseRef    `json:"release"`
+	Source  string        `json:"source,omitempty"`
+	Created string        `json:"created,omitempty"`
+	Checks  []VerifyCheck `json:"checks"`
+}
+
+var bundleVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify an evidence bundle offline",
+	Long: `Verifies a bundle produced by bundle export without contacting ReARM:
+
+  files              every file matches the sha256 and size recorded in the manifest,
+                     and no unlisted files are present
+  signature          manifest.json matches manifest.json.sig for --pubkey-file
+  artifact-digests   every artifact file matches the digests recorded for it in release.json
+  bom:<deliverable>  the CycloneDX BOMs of each deliverable describe a subject with one
+                     of the deliverable digests (metadata component hashes, purl or bom-ref)
+
+A signed bundle verified without --pubkey-file reports the signature as not checked;
+with --pubkey-file an unsigned bundle fails. The report is printed as JSON (or text with
+--format text) and the command exits with code 1 when any check fails.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, manifest, err := ExtractBundle(bundleFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error reading bundle:", err)
+			exitCommand(1)
+		}
+		result := verifyBundle(dir, manifest)
+		os.RemoveAll(dir)
+		result.Bundle = bundleFile
+
+		if bundleVerifyFormat == "text" {
+			printBundleVerification(result)
+		} else {
+			emitJson(result)
+		}
+		if result.Verdict != verdictPass {
+			exitCommand(1)
+		}
+	},
+}
+
+func verifyBundle(dir string, manifest *BundleManifest) BundleVerification {
+	result := BundleVerification{
+		Verdict: verdictPass,
+		Release: manifest.Release.ReleaseRef,
+		Source:  manifest.Source,
+		Created: manifest.Created,
+		Checks:  []VerifyCheck{},
+	}
+
+	files := VerifyCheck{Name: "files", Passed: true, Detail: fmt.Sprintf("%d file(s)", len(manifest.Files))}
+	if problems := CheckBundleFiles(dir, manifest); len(problems) > 0 {
+		files.Passed = false
+		files.Detail = strings.Join(problems, "; ")
+	}
+	result.Checks = append(result.Checks, files)
+	result.Checks = append(result.Checks, verifyBundleSignature(dir, manifest))
+
+	var rlz releaseContent
+	if err := readBundleRelease(dir, &rlz); err != nil {
+		result.Checks = append(result.Checks, VerifyCheck{Name: "release", Passed: false, Detail: err.Error()})
+	} else {
+		result.Checks = append(result.Checks, verifyBundleArtifactDigests(dir, manifest, &rlz))
+		result.Checks = append(result.Checks, verifyBundleBoms(dir, manifest, &rlz)...)
+	}
+
+	for _, c := range result.Checks {
+		if !c.Passed {
+			result.Verdict = verdictFail
+		}
+	}
+	return result
+}
+
+// verifyBundleArtifactDigests hashes each artifact file in the bundle and
+// compares it with the digests recorded for the artifact in release.json.
+func verifyBundleArtifactDigests(dir string, manifest *BundleManifest, rlz *releaseContent) VerifyCheck {
+	c := VerifyCheck{Name: "artifact-digests", Passed: true}
+	records := make(map[string][]DigestRecord)
+	for _, a := range rlz.allArtifacts() {
+		records[a.Uuid] = a.DigestRecords
+	}
+	var mismatches []string
+	unchecked := 0
+	for _, a := range manifest.Artifacts {
+		if a.Path == "" {
+			continue
+		}
+		recorded, ok := records[a.Uuid]
+		if !ok {
+			mismatches = append(mismatches, a.Path+": artifact "+a.Uuid+" is not in "+bundleReleaseFile)
+			continue
+		}
+		sha256Hex, sha512Hex, err := fileDigests(filepath.Join(dir, filepath.FromSlash(a.Path)))
+		if err != nil {
+			mismatches = append(mismatches, a.Path+": "+err.Error())
+			continue
+		}
+		verdict, detail := verifyDigestRecords(recorded, sha256Hex, sha512Hex)
+		switch verdict {
+		case downloadMismatch:
+			mismatches = append(mismatches, a.Path+": "+detail)
+		case downloadNoDigest:
+			unchecked++
+		}
+	}
+	if len(mismatches) > 0 {
+		c.Passed = false
+		c.Detail = strings.Join(mismatches, "; ")
+	} else if unchecked > 0 {
+		c.Detail = fmt.Sprintf("%d artifact(s) have no recorded digest", unchecked)
+	}
+	return c
+}
+
+func readBundleRelease(dir string, target interface{}) error {
+	raw, err := os.ReadFile(filepath.Join(dir, bundleReleaseFile))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, target); err != nil {
+		return fmt.Errorf("parsing %s: %w", bundleReleaseFile, err)
+	}
+	return nil
+}
+
+func verifyBundleSignature(dir string, manifest *BundleManifest) VerifyCheck {
+	c := VerifyCheck{Name: "signature", Passed: true}
+	if manifest.Signature == nil {
+		if bundleVerifyPubkeyFile != "" {
+			c.Passed = false
+		}
+		c.Detail = "bundle is not signed"
+		return c
+	}
+	if bundleVerifyPubkeyFile == "" {
+		c.Detail = "not checked, pass --pubkey-file"
+		return c
+	}
+	c.Passed = false
+	pubKey, err := os.ReadFile(bundleVerifyPubkeyFile)
+	if err != nil {
+		c.Detail = err.Error()
+		return c
+	}
+	data, err := os.ReadFile(filepath.Join(dir, bundleManifestFile))
+	if err != nil {
+		c.Detail = err.Error()
+		return c
+	}
+	sig, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(manifest.Signature.File)))
+	if err != nil {
+		c.Detail = "signature file is missing"
+		return c
+	}
+	format, err := verifyDetachedSignature(data, sig, pubKey)
+	if err != nil {
+		c.Detail = err.Error()
+		return c
+	}
+	c.Passed = true
+	c.Detail = format + " signature"
+	if manifest.Signature.KeyId != "" {
+		c.Detail += " by " + manifest.Signature.KeyId
+	}
+	return c
+}
+
+// verifyBundleBoms checks, for every deliverable with digests and CycloneDX
+// BOMs, that at least one of its BOMs describes a subject with one of the
+// deliverable digests.
+func verifyBundleBoms(dir string, manifest *BundleManifest, rlz *releaseContent) []VerifyCheck {
+	checks := []VerifyCheck{}
+	for _, d := range rlz.deliverables() {
+		if len(d.SoftwareMetadata.Digests) == 0 {
+			continue
+		}
+		delivered := map[string]bool{}
+		for _, dg := range d.SoftwareMetadata.Digests {
+			if algo, value, err := parseDigestArg(dg); err == nil {
+				delivered[algo+":"+value] = true
+			}
+		}
+		c := VerifyCheck{Name: "bom:" + d.DisplayIdentifier, Passed: true}
+		boms := 0
+		var problems []string
+		for _, a := range manifest.Artifacts {
+			if a.DeliverableUuid != d.Uuid || a.Type != "BOM" || (a.BomFormat != "" && a.BomFormat != "CYCLONEDX") {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(a.Path)))
+			if err != nil {
+				problems = append(problems, a.Path+": "+err.Error())
+				continue
+			}
+			bom, err := parseBom(data)
+			if err != nil {
+				problems = append(problems, a.Path+": not a CycloneDX JSON BOM")
+				continue
+			}
+			boms++
+			matched := false
+			for dg := range bomSubjectDigests(bom) {
+				if delivered[dg] {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				problems = append(problems, a.Path+": BOM subject does not carry a deliverable digest")
+			}
+		}
+		if boms == 0 && len(problems) == 0 {
+			continue
+		}
+		if len(problems) > 0 {
+			c.Passed = false
+			c.Detail = strings.Join(problems, "; ")
+		} else {
+			c.Detail = fmt.Sprintf("%d BOM(s)", boms)
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+// bomSubjectDigests collects the sha256 / sha512 digests a BOM records for its
+// subject, from the hashes, purl and bom-ref of the metadata component, in
+// algo:hex form.
+func bomSubjectDigests(bom *cdx.BOM) map[string]bool {
+	out := map[string]bool{}
+	if bom.Metadata == nil || bom.Metadata.Component == nil {
+		return out
+	}
+	c := bom.Metadata.Component
+	add := func(algo string, value string) {
+		if d := normalizeSha256(algo, value); d != "" {
+			out["sha256:"+d] = true
+		} else if d := normalizeSha512(algo, value); d != "" {
+			out["sha512:"+d] = true
+		}
+	}
+	if c.Hashes != nil {
+		for _, h := range *c.Hashes {
+			add(string(h.Algorithm), h.Value)
+		}
+	}
+	for _, ref := range []string{c.PackageURL, c.BOMRef} {
+		for _, m := range bomDigestPattern.FindAllStringSubmatch(ref, -1) {
+			add(m[1], m[2])
+		}
+	}
+	return out
+}
+
+func printBundleVerification(r BundleVerification) {
+	fmt.Printf("%s %s: %s %s (%s)\n", r.Verdict, r.Bundle, r.Release.Component, r.Release.Version, r.Release.Uuid)
+	if r.Source != "" {
+		fmt.Printf("  exported from %s at %s\n", r.Source, r.Created)
+	}
+	for _, c := range r.Checks {
+		status := "ok"
+		if !c.Passed {
+			status = "FAILED"
+		}
+		if c.Detail != "" {
+			fmt.Printf("  %-16s %s: %s\n", c.Name, status, c.Detail)
+		} else {
+			fmt.Printf("  %-16s %s\n", c.Name, status)
+		}
+	}
+}
+
+func init() {
+	bundleVerifyCmd.PersistentFlags().StringVar(&bundleFile, "bundle", "", "Path of the bundle to verify")
+	bundleVerifyCmd.PersistentFlags().StringVar(&bundleVerifyPubkeyFile, "pubkey-file", "", "PEM or SSH public key to verify the manifest signature with (optional)")
+	bundleVerifyCmd.PersistentFlags().StringVar(&bundleVerifyFormat, "format", "json", "Output format: json or text")
+	bundleVerifyCmd.MarkPersistentFlagRequired("bundle")
+
+	bundleCmd.AddCommand(bundleVerifyCmd)
+}
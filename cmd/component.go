@@ -0,0 +1,402 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Flag variables for the component, branch and vcs management commands are
+// kept separate from the createcomponent globals so that defaults registered
+// there (e.g. --versionschema semver) do not leak into updates.
+var (
+	mgmtOrg                     string
+	mgmtComponent               string
+	mgmtComponentType           string
+	mgmtName                    string
+	mgmtVersionSchema           string
+	mgmtFeatureBranchVersioning string
+	mgmtVcs                     string
+	mgmtVcsUri                  string
+	mgmtVcsType                 string
+	mgmtApprovalPolicy          string
+	mgmtBranch                  string
+	mgmtNextVersion             string
+	mgmtVersionType             string
+)
+
+const BRANCH_GQL_DATA = `
+	uuid
+	name
+	component
+	status
+	type
+	vcs
+	vcsBranch
+	versionSchema
+	marketingVersionSchema
+	versionType
+`
+
+const VCS_REPOSITORY_GQL_DATA = `
+	uuid
+	name
+	org
+	uri
+	type
+`
+
+var componentCmd = &cobra.Command{
+	Use:   "component",
+	Short: "Manage components and products",
+	Long:  `Set of commands to list, inspect, update and archive components and products.`,
+}
+
+var componentListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List components or products of an organization",
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM at", rearmUri)
+		}
+		compType := strings.ToUpper(mgmtComponentType)
+		if compType != "COMPONENT" && compType != "PRODUCT" && compType != "ANY" {
+			fmt.Fprintln(os.Stderr, "Error: --type must be one of COMPONENT, PRODUCT, ANY")
+			exitCommand(2)
+		}
+		query := `
+			query ($orgUuid: ID!, $componentType: ComponentType!) {
+				components(orgUuid: $orgUuid, componentType: $componentType) {` + COMPONENT_GQL_DATA + `}
+			}
+		`
+		variables := map[string]interface{}{"orgUuid": mgmtOrg, "componentType": compType}
+		fmt.Println(sendRequest(query, variables, "components"))
+	},
+}
+
+var componentGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show a single component or product",
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM at", rearmUri)
+		}
+		query := `
+			query ($componentUuid: ID!) {
+				component(componentUuid: $componentUuid) {` + COMPONENT_GQL_DATA + `}
+			}
+		`
+		fmt.Println(sendRequest(query, map[string]interface{}{"componentUuid": mgmtComponent}, "component"))
+	},
+}
+
+var componentUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update settings of a component or product",
+	Long: `Updates only the settings passed as flags; everything else is left as is.
+The component name is required by the backend on every update, so when --name is not
+supplied the current name is fetched and resubmitted.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM at", rearmUri)
+		}
+		name := mgmtName
+		if len(name) == 0 {
+			data, err := sendGraphQLRequest(`
+				query ($componentUuid: ID!) {
+					component(componentUuid: $componentUuid) { name }
+				}
+			`, map[string]interface{}{"componentUuid": mgmtComponent}, rearmUri+"/graphql")
+			if err != nil {
+				printGqlError(err)
+				exitCommand(1)
+			}
+			comp, ok := data["component"].(map[string]interface{})
+			if !ok {
+				fmt.Fprintln(os.Stderr, "Error: component", mgmtComponent, "not found")
+				exitCommand(1)
+			}
+			name, _ = comp["name"].(string)
+		}
+
+		body := map[string]interface{}{"uuid": mgmtComponent, "name": name}
+		if len(mgmtVersionSchema) > 0 {
+			body["versionSchema"] = mgmtVersionSchema
+		}
+		if len(mgmtFeatureBranchVersioning) > 0 {
+			body["featureBranchVersioning"] = mgmtFeatureBranchVersioning
+		}
+		if len(mgmtVcs) > 0 {
+			body["vcs"] = mgmtVcs
+		}
+		if len(mgmtApprovalPolicy) > 0 {
+			body["approvalPolicy"] = mgmtApprovalPolicy
+		}
+
+		query := `
+			mutation ($component: UpdateComponentInput!) {
+				updateComponent(component: $component) {` + COMPONENT_GQL_DATA + `}
+			}
+		`
+		fmt.Println(sendRequest(query, map[string]interface{}{"component": body}, "updateComponent"))
+	},
+}
+
+var componentArchiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Archive a component or product",
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM at", rearmUri)
+		}
+		query := `
+			mutation ($componentUuid: ID!) {
+				archiveComponent(componentUuid: $componentUuid)
+			}
+		`
+		fmt.Println(sendRequest(query, map[string]interface{}{"componentUuid": mgmtComponent}, "archiveComponent"))
+	},
+}
+
+var branchCmd = &cobra.Command{
+	Use:   "branch",
+	Short: "Manage branches and feature sets",
+	Long:  `Set of commands to list, create and archive branches (feature sets for products) and to set the next version of a branch.`,
+}
+
+var branchListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List branches of a component or feature sets of a product",
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM at", rearmUri)
+		}
+		query := `
+			query ($componentUuid: ID!) {
+				branchesOfComponent(componentUuid: $componentUuid) {` + BRANCH_GQL_DATA + `}
+			}
+		`
+		fmt.Println(sendRequest(query, map[string]interface{}{"componentUuid": mgmtComponent}, "branchesOfComponent"))
+	},
+}
+
+var branchCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a branch of a component or feature set of a product",
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM at", rearmUri)
+		}
+		query := `
+			mutation ($componentUuid: ID!, $name: String!, $versionSchema: String) {
+				createBranch(componentUuid: $componentUuid, name: $name, versionSchema: $versionSchema) {` + BRANCH_GQL_DATA + `}
+			}
+		`
+		variables := map[string]interface{}{"componentUuid": mgmtComponent, "name": mgmtName}
+		if len(mgmtVersionSchema) > 0 {
+			variables["versionSchema"] = mgmtVersionSchema
+		}
+		fmt.Println(sendRequest(query, variables, "createBranch"))
+	},
+}
+
+var branchArchiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Archive a branch or feature set",
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM at", rearmUri)
+		}
+		query := `
+			mutation ($branchUuid: ID!) {
+				archiveBranch(branchUuid: $branchUuid)
+			}
+		`
+		fmt.Println(sendRequest(query, map[string]interface{}{"branchUuid": mgmtBranch}, "archiveBranch"))
+	},
+}
+
+var branchSetNextVersionCmd = &cobra.Command{
+	Use:   "set-next-version",
+	Short: "Set the version the next release of a branch will receive",
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM at", rearmUri)
+		}
+		query := `
+			mutation ($branchUuid: ID!, $versionString: String!, $versionType: VersionType) {
+				setNextVersion(branchUuid: $branchUuid, versionString: $versionString, versionType: $versionType)
+			}
+		`
+		variables := map[string]interface{}{"branchUuid": mgmtBranch, "versionString": mgmtNextVersion}
+		if len(mgmtVersionType) > 0 {
+			vt := strings.ToUpper(mgmtVersionType)
+			if vt != "DEV" && vt != "MARKETING" {
+				fmt.Fprintln(os.Stderr, "Error: --versiontype must be DEV or MARKETING")
+				exitCommand(2)
+			}
+			variables["versionType"] = vt
+		}
+		fmt.Println(sendRequest(query, variables, "setNextVersion"))
+	},
+}
+
+var vcsCmd = &cobra.Command{
+	Use:   "vcs",
+	Short: "Manage VCS repositories",
+	Long:  `Set of commands to list, create and update VCS repositories of an organization.`,
+}
+
+var vcsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List VCS repositories of an organization",
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM at", rearmUri)
+		}
+		query := `
+			query ($orgUuid: ID!) {
+				listVcsReposOfOrganization(orgUuid: $orgUuid) {` + VCS_REPOSITORY_GQL_DATA + `}
+			}
+		`
+		fmt.Println(sendRequest(query, map[string]interface{}{"orgUuid": mgmtOrg}, "listVcsReposOfOrganization"))
+	},
+}
+
+var vcsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a VCS repository",
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM at", rearmUri)
+		}
+		body := map[string]interface{}{"org": mgmtOrg, "name": mgmtName, "uri": mgmtVcsUri}
+		if len(mgmtVcsType) > 0 {
+			body["type"] = mgmtVcsType
+		}
+		query := `
+			mutation ($vcsRepository: VcsRepositoryInput!) {
+				createVcsRepository(vcsRepository: $vcsRepository) {` + VCS_REPOSITORY_GQL_DATA + `}
+			}
+		`
+		fmt.Println(sendRequest(query, map[string]interface{}{"vcsRepository": body}, "createVcsRepository"))
+	},
+}
+
+var vcsUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Rename a VCS repository or change its URI",
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM at", rearmUri)
+		}
+		if len(mgmtName) == 0 && len(mgmtVcsUri) == 0 {
+			fmt.Fprintln(os.Stderr, "Error: at least one of --name or --uri must be set")
+			exitCommand(2)
+		}
+		variables := map[string]interface{}{"vcsUuid": mgmtVcs}
+		if len(mgmtName) > 0 {
+			variables["name"] = mgmtName
+		}
+		if len(mgmtVcsUri) > 0 {
+			variables["uri"] = mgmtVcsUri
+		}
+		query := `
+			mutation ($vcsUuid: ID!, $name: String, $uri: String) {
+				updateVcsRepository(vcsUuid: $vcsUuid, name: $name, uri: $uri) {` + VCS_REPOSITORY_GQL_DATA + `}
+			}
+		`
+		fmt.Println(sendRequest(query, variables, "updateVcsRepository"))
+	},
+}
+
+func init() {
+	componentListCmd.PersistentFlags().StringVar(&mgmtOrg, "org", "", "UUID of the organization")
+	componentListCmd.MarkPersistentFlagRequired("org")
+	componentListCmd.PersistentFlags().StringVar(&mgmtComponentType, "type", "ANY", "Type to list: COMPONENT, PRODUCT or ANY")
+
+	componentGetCmd.PersistentFlags().StringVar(&mgmtComponent, "component", "", "UUID of the component or product")
+	componentGetCmd.MarkPersistentFlagRequired("component")
+
+	componentUpdateCmd.PersistentFlags().StringVar(&mgmtComponent, "component", "", "UUID of the component or product")
+	componentUpdateCmd.PersistentFlags().StringVar(&mgmtName, "name", "", "(Optional) New name")
+	componentUpdateCmd.PersistentFlags().StringVar(&mgmtVersionSchema, "versionschema", "", "(Optional) New version schema")
+	componentUpdateCmd.PersistentFlags().StringVar(&mgmtFeatureBranchVersioning, "featurebranchversioning", "", "(Optional) New feature branch version schema")
+	componentUpdateCmd.PersistentFlags().StringVar(&mgmtVcs, "vcsuuid", "", "(Optional) UUID of the VCS repository to link")
+	componentUpdateCmd.PersistentFlags().StringVar(&mgmtApprovalPolicy, "approvalpolicy", "", "(Optional) UUID of the approval policy to apply")
+	componentUpdateCmd.MarkPersistentFlagRequired("component")
+
+	componentArchiveCmd.PersistentFlags().StringVar(&mgmtComponent, "component", "", "UUID of the component or product")
+	componentArchiveCmd.MarkPersistentFlagRequired("component")
+
+	componentCmd.AddCommand(componentListCmd)
+	componentCmd.AddCommand(componentGetCmd)
+	componentCmd.AddCommand(componentUpdateCmd)
+	componentCmd.AddCommand(componentArchiveCmd)
+
+	branchListCmd.PersistentFlags().StringVar(&mgmtComponent, "component", "", "UUID of the component or product")
+	branchListCmd.MarkPersistentFlagRequired("component")
+
+	branchCreateCmd.PersistentFlags().StringVar(&mgmtComponent, "component", "", "UUID of the component or product")
+	branchCreateCmd.PersistentFlags().StringVar(&mgmtName, "name", "", "Name of the branch or feature set")
+	branchCreateCmd.PersistentFlags().StringVar(&mgmtVersionSchema, "versionschema", "", "(Optional) Version schema of the branch, defaults to the component's feature branch versioning")
+	branchCreateCmd.MarkPersistentFlagRequired("component")
+	branchCreateCmd.MarkPersistentFlagRequired("name")
+
+	branchArchiveCmd.PersistentFlags().StringVar(&mgmtBranch, "branch", "", "UUID of the branch or feature set")
+	branchArchiveCmd.MarkPersistentFlagRequired("branch")
+
+	branchSetNextVersionCmd.PersistentFlags().StringVar(&mgmtBranch, "branch", "", "UUID of the branch")
+	branchSetNextVersionCmd.PersistentFlags().StringVar(&mgmtNextVersion, "nextversion", "", "Version the next release of the branch should receive")
+	branchSetNextVersionCmd.PersistentFlags().StringVar(&mgmtVersionType, "versiontype", "", "(Optional) DEV or MARKETING")
+	branchSetNextVersionCmd.MarkPersistentFlagRequired("branch")
+	branchSetNextVersionCmd.MarkPersistentFlagRequired("nextversion")
+
+	branchCmd.AddCommand(branchListCmd)
+	branchCmd.AddCommand(branchCreateCmd)
+	branchCmd.AddCommand(branchArchiveCmd)
+	branchCmd.AddCommand(branchSetNextVersionCmd)
+
+	vcsListCmd.PersistentFlags().StringVar(&mgmtOrg, "org", "", "UUID of the organization")
+	vcsListCmd.MarkPersistentFlagRequired("org")
+
+	vcsCreateCmd.PersistentFlags().StringVar(&mgmtOrg, "org", "", "UUID of the organization")
+	vcsCreateCmd.PersistentFlags().StringVar(&mgmtName, "name", "", "Display name of the repository")
+	vcsCreateCmd.PersistentFlags().StringVar(&mgmtVcsUri, "uri", "", "URI of the repository")
+	vcsCreateCmd.PersistentFlags().StringVar(&mgmtVcsType, "type", "", "(Optional) Type of the repository, e.g. Git")
+	vcsCreateCmd.MarkPersistentFlagRequired("org")
+	vcsCreateCmd.MarkPersistentFlagRequired("name")
+	vcsCreateCmd.MarkPersistentFlagRequired("uri")
+
+	vcsUpdateCmd.PersistentFlags().StringVar(&mgmtVcs, "vcsuuid", "", "UUID of the VCS repository")
+	vcsUpdateCmd.PersistentFlags().StringVar(&mgmtName, "name", "", "(Optional) New display name")
+	vcsUpdateCmd.PersistentFlags().StringVar(&mgmtVcsUri, "uri", "", "(Optional) New URI")
+	vcsUpdateCmd.MarkPersistentFlagRequired("vcsuuid")
+
+	vcsCmd.AddCommand(vcsListCmd)
+	vcsCmd.AddCommand(vcsCreateCmd)
+	vcsCmd.AddCommand(vcsUpdateCmd)
+
+	rootCmd.AddCommand(componentCmd)
+	rootCmd.AddCommand(branchCmd)
+	rootCmd.AddCommand(vcsCmd)
+}
@@ -93,7 +93,7 @@ var convertSpdxCmd = &cobra.Command{
 		err := convertSpdxToCycloneDx(spdxInputFile, spdxOutputFile, validateOutput)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 	},
 }
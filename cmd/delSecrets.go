@@ -20,7 +20,6 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -52,7 +51,7 @@ var deliverableGetSecrets = &cobra.Command{
 
 		if len(instance) <= 0 && len(instanceURI) <= 0 && !strings.HasPrefix(apiKeyId, "INSTANCE__") && !strings.HasPrefix(apiKeyId, "CLUSTER__") {
 			fmt.Println("instance or instanceURI not specified!")
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		if len(namespace) <= 1 {
@@ -78,7 +77,7 @@ var deliverableGetSecrets = &cobra.Command{
 		data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
 		if err != nil {
 			printGqlError(err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		if secretData, ok := data["deliverableDownloadSecrets"].(map[string]interface{}); ok {
@@ -115,7 +114,7 @@ var isInstHasSecretCertCmd = &cobra.Command{
 		data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
 		if err != nil {
 			printGqlError(err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		var respData IsHasCertRHResp
@@ -20,7 +20,6 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 
@@ -76,7 +75,7 @@ var setInstSecretCertCmd = &cobra.Command{
 		data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
 		if err != nil {
 			printGqlError(err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		type SetCertRHResp struct {
@@ -95,7 +94,7 @@ var setInstSecretCertCmd = &cobra.Command{
 func getInstanceRevisionCycloneDxExportV1(apiKeyId string, instance string, revision string, instanceURI string, namespace string, stateType string) []byte {
 	if len(instance) <= 0 && len(instanceURI) <= 0 && !strings.HasPrefix(apiKeyId, "INSTANCE__") && !strings.HasPrefix(apiKeyId, "CLUSTER__") {
 		fmt.Println("instance or instanceURI not specified!")
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	if len(revision) < 1 {
@@ -127,7 +126,7 @@ func getInstanceRevisionCycloneDxExportV1(apiKeyId string, instance string, revi
 	data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
 	if err != nil {
 		printGqlError(err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	if result, ok := data["getInstanceRevisionCycloneDxExportProg"].(string); ok {
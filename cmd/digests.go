@@ -0,0 +1,108 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"os"
+	"strings"
+)
+
+// skipDigestRecords turns off the digest records the CLI computes for every
+// uploaded artifact file.
+var skipDigestRecords bool
+
+// computeDigestRecords returns the sha256 and sha512 digest records of an
+// artifact file in algo:hex form, as accepted by ArtifactInput.digestRecords.
+func computeDigestRecords(b []byte) []string {
+	s256 := sha256.Sum256(b)
+	s512 := sha512.Sum512(b)
+	return []string{
+		"sha256:" + hex.EncodeToString(s256[:]),
+		"sha512:" + hex.EncodeToString(s512[:]),
+	}
+}
+
+// withComputedDigests adds computed digest records to those supplied by the
+// caller, keeping caller-supplied values and dropping duplicates.
+func withComputedDigests(existing []string, b []byte) []string {
+	if skipDigestRecords {
+		return existing
+	}
+	seen := make(map[string]bool)
+	var out []string
+	for _, d := range append(append([]string{}, existing...), computeDigestRecords(b)...) {
+		key := strings.ToLower(d)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, d)
+	}
+	return out
+}
+
+func fileSha256(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// fileDigests returns the lower-case hex sha256 and sha512 of a file.
+func fileDigests(path string) (string, string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", err
+	}
+	s256 := sha256.Sum256(b)
+	s512 := sha512.Sum512(b)
+	return hex.EncodeToString(s256[:]), hex.EncodeToString(s512[:]), nil
+}
+
+// normalizeSha256 returns the lower-case hex sha256 of a digest record, or an
+// empty string for other algorithms. Accepts both SHA_256 style algorithm
+// names and sha256: prefixed digests.
+func normalizeSha256(algo string, digest string) string {
+	a := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(algo))
+	d := strings.ToLower(strings.TrimSpace(digest))
+	if strings.HasPrefix(d, "sha256:") {
+		return strings.TrimPrefix(d, "sha256:")
+	}
+	if a == "sha256" {
+		return d
+	}
+	return ""
+}
+
+// normalizeSha512 is the sha512 counterpart of normalizeSha256.
+func normalizeSha512(algo string, digest string) string {
+	a := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(algo))
+	d := strings.ToLower(strings.TrimSpace(digest))
+	if strings.HasPrefix(d, "sha512:") {
+		return strings.TrimPrefix(d, "sha512:")
+	}
+	if a == "sha512" {
+		return d
+	}
+	return ""
+}
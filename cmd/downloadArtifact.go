@@ -18,11 +18,16 @@ WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
 package cmd
 
 import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/spf13/cobra"
@@ -32,6 +37,7 @@ var (
 	dlArtifactUuid    string
 	artifactVersion int
 	rawDownload     bool
+	expectedSha256  string
 )
 
 func init() {
@@ -42,6 +48,7 @@ func init() {
 	downloadArtifactCmd.PersistentFlags().StringVar(&outfile, "outfile", "", "Override filename for the downloaded file (optional, default taken from Content-Disposition header)")
 	downloadArtifactCmd.PersistentFlags().BoolVar(&rawDownload, "raw", false, "Download raw artifact instead of processed BOM (optional, default false)")
 	downloadArtifactCmd.PersistentFlags().IntVar(&artifactVersion, "version", 0, "Artifact version to download (optional)")
+	downloadArtifactCmd.PersistentFlags().StringVar(&expectedSha256, "sha256", "", "Expected sha256 digest of the downloaded file, the download fails on mismatch (optional)")
 	rootCmd.AddCommand(downloadArtifactCmd)
 }
 
@@ -56,6 +63,13 @@ raw (unprocessed) artifact instead.
 The output filename is taken from the Content-Disposition header returned by
 the server. Use --outfile to override it with a custom filename.
 
+The file is streamed to a temporary .part file in the output directory and
+renamed once complete. Interrupted transfers are resumed with HTTP Range
+requests, also when the command is run again after a failure. The ETag or
+Last-Modified of the first response is kept next to the .part file and sent
+as If-Range, so a partial file of an older artifact version is never
+completed with newer content.
+
 Examples:
   rearm-cli downloadartifact -i $APIKEY_ID -k $APIKEY_SECRET -u $REARM_URI \
     --artifactuuid <artifact-uuid> --outdirectory ./downloads
@@ -67,81 +81,370 @@ Examples:
 
   # Download a specific version:
   rearm-cli downloadartifact -i $APIKEY_ID -k $APIKEY_SECRET -u $REARM_URI \
-    --artifactuuid <artifact-uuid> --outdirectory ./downloads --version 2`,
+    --artifactuuid <artifact-uuid> --outdirectory ./downloads --version 2
+
+  # Verify the downloaded file against a known digest:
+  rearm-cli downloadartifact -i $APIKEY_ID -k $APIKEY_SECRET -u $REARM_URI \
+    --artifactuuid <artifact-uuid> --outdirectory ./downloads --raw \
+    --sha256 <sha256-hex>`,
 	Run: func(cmd *cobra.Command, args []string) {
 		downloadArtifactFunc()
 	},
 }
 
 func downloadArtifactFunc() {
-	endpoint := "/download"
-	if rawDownload {
-		endpoint = "/rawdownload"
-	}
-	url := rearmUri + "/api/programmatic/v1/artifact/" + dlArtifactUuid + endpoint
-
 	if debug == "true" {
 		fmt.Println("Using ReARM at", rearmUri)
-		fmt.Println("Downloading artifact", dlArtifactUuid, "from", url)
+		fmt.Println("Downloading artifact", dlArtifactUuid)
 	}
 
-	client := resty.New()
-	applySessionToRestyClient(client)
-	req := client.R().
-		SetHeader("User-Agent", "ReARM CLI").
-		SetHeader("Accept-Encoding", "identity"). // disable compression so Body() is raw bytes
-		SetBasicAuth(apiKeyId, apiKey)
-
-	if artifactVersion > 0 {
-		req = req.SetQueryParam("version", strconv.Itoa(artifactVersion))
+	var progress io.Writer
+	if fi, err := os.Stderr.Stat(); err == nil && fi.Mode()&os.ModeCharDevice != 0 {
+		progress = os.Stderr
 	}
+	dl, err := streamArtifactToDir(dlArtifactUuid, rawDownload, artifactVersion, outDirectory, outfile, dlArtifactUuid+".bin", expectedSha256, progress)
+	if err != nil {
+		fmt.Println("Error downloading artifact:", err)
+		exitCommand(1)
+	}
+
+	fmt.Println(dl.Path)
+}
 
+// downloadArtifactBytes fetches an artifact from the programmatic download
+// endpoint and returns its content together with the filename advertised in
+// the Content-Disposition header (empty when the server sends none).
+func downloadArtifactBytes(artifactUuid string, raw bool, artVersion int) ([]byte, string, error) {
+	req, url := newArtifactDownloadRequest(artifactUuid, raw, artVersion)
 	resp, err := req.Get(url)
 	if err != nil {
-		fmt.Println("Error downloading artifact:", err)
-		os.Exit(1)
+		return nil, "", err
 	}
 
 	if resp.StatusCode() != 200 {
-		fmt.Println("Error: server returned status", resp.Status())
 		if debug == "true" {
-			fmt.Println("Response body:", resp.String())
+			fmt.Fprintln(os.Stderr, "Response body:", resp.String())
 		}
-		os.Exit(1)
+		return nil, "", fmt.Errorf("server returned status %s", resp.Status())
 	}
 
-	// Determine output filename
-	filename := outfile
-	if filename == "" {
-		cd := resp.Header().Get("Content-Disposition")
-		for _, part := range strings.Split(cd, ";") {
-			part = strings.TrimSpace(part)
-			if strings.HasPrefix(part, "filename=") {
-				filename = strings.Trim(strings.TrimPrefix(part, "filename="), `"`)
-				break
+	return resp.Body(), contentDispositionFilename(resp.Header().Get("Content-Disposition")), nil
+}
+
+// artifactDownload describes a file written by streamArtifactToDir.
+type artifactDownload struct {
+	Path   string
+	Size   int64
+	Sha256 string
+	Sha512 string
+}
+
+// downloadAttempts bounds how often an interrupted download is resumed
+// before giving up.
+const downloadAttempts = 5
+
+// streamArtifactToDir downloads an artifact into dir without buffering it in
+// memory, hashing the content on the way. Data is written to a .part file
+// which is resumed with an HTTP Range request when the transfer is
+// interrupted, including by a previous run, and renamed into place once
+// complete. A .part file left by a previous run is only resumed when its
+// validator was stored, see loadPartValidator. The filename is name when set, otherwise it comes from the
+// Content-Disposition header, falling back to fallbackName. Progress is
+// rendered on progress when non-nil. When expectedSha256 is set the download
+// fails on mismatch and the partial file is removed.
+func streamArtifactToDir(artifactUuid string, raw bool, artVersion int, dir string, name string, fallbackName string, expectedSha256 string, progress io.Writer) (*artifactDownload, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	partPath := filepath.Join(dir, "."+artifactUuid+partFileSuffix(raw, artVersion))
+	validator := loadPartValidator(partPath)
+
+	var spinner *progressSpinner
+	if progress != nil {
+		spinner = startProgressSpinner(progress, "Downloading "+artifactUuid)
+		defer spinner.Stop()
+	}
+
+	var serverName string
+	var lastErr error
+	for attempt := 1; attempt <= downloadAttempts; attempt++ {
+		if attempt > 1 {
+			if debug == "true" {
+				fmt.Fprintln(os.Stderr, "Resuming download after error:", lastErr)
 			}
+			time.Sleep(time.Duration(attempt-1) * time.Second)
+		}
+		cdName, done, err := fetchArtifactPart(artifactUuid, raw, artVersion, partPath, &validator, spinner)
+		if cdName != "" {
+			serverName = cdName
+		}
+		if done {
+			lastErr = nil
+			break
+		}
+		lastErr = err
+		if _, retry := err.(retryableDownloadError); !retry {
+			break
 		}
 	}
+	if lastErr != nil {
+		if e, ok := lastErr.(retryableDownloadError); ok {
+			lastErr = e.err
+		}
+		return nil, lastErr
+	}
+
+	dl, err := hashFile(partPath)
+	if err != nil {
+		return nil, err
+	}
+	if expectedSha256 != "" {
+		want := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(expectedSha256), "sha256:"))
+		if dl.Sha256 != want {
+			removePartFile(partPath)
+			return nil, fmt.Errorf("sha256 mismatch: expected %s, got %s", want, dl.Sha256)
+		}
+	}
+
+	filename := name
 	if filename == "" {
-		filename = dlArtifactUuid + ".bin"
+		filename = filepath.Base(serverName)
 	}
+	if filename == "" || filename == "." || filename == string(filepath.Separator) {
+		filename = sanitizeFilename(fallbackName)
+	}
+	dl.Path = filepath.Join(dir, filename)
+	if err := os.Rename(partPath, dl.Path); err != nil {
+		return nil, err
+	}
+	os.Remove(partValidatorPath(partPath))
+	return dl, nil
+}
 
-	if debug == "true" {
-		fmt.Println("Content-Disposition:", resp.Header().Get("Content-Disposition"))
-		fmt.Println("Writing to filename:", filename)
+// retryableDownloadError marks transfer errors after which the download can
+// be resumed.
+type retryableDownloadError struct {
+	err error
+}
+
+func (e retryableDownloadError) Error() string {
+	return e.err.Error()
+}
+
+func partFileSuffix(raw bool, artVersion int) string {
+	suffix := ""
+	if raw {
+		suffix += ".raw"
+	}
+	if artVersion > 0 {
+		suffix += ".v" + strconv.Itoa(artVersion)
 	}
+	return suffix + ".part"
+}
 
-	// Ensure output directory exists
-	if err := os.MkdirAll(outDirectory, 0755); err != nil {
-		fmt.Println("Error creating output directory:", err)
-		os.Exit(1)
+func partValidatorPath(partPath string) string {
+	return partPath + ".validator"
+}
+
+// loadPartValidator returns the validator stored for a .part file left by a
+// previous run. Without a stored validator the content of the partial file
+// cannot be matched to the artifact served now, for example when the latest
+// version changed in between, so the partial file is removed and the
+// download starts over.
+func loadPartValidator(partPath string) string {
+	b, err := os.ReadFile(partValidatorPath(partPath))
+	validator := strings.TrimSpace(string(b))
+	if err != nil || validator == "" {
+		removePartFile(partPath)
+		return ""
 	}
+	return validator
+}
 
-	outPath := filepath.Join(outDirectory, filename)
-	if err := os.WriteFile(outPath, resp.Body(), 0644); err != nil {
-		fmt.Println("Error writing file:", err)
-		os.Exit(1)
+// responseValidator returns the value to send as If-Range when resuming the
+// response. Weak ETags are not allowed in If-Range, Last-Modified is used
+// instead.
+func responseValidator(resp *resty.Response) string {
+	if etag := resp.Header().Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
 	}
+	return resp.Header().Get("Last-Modified")
+}
 
-	fmt.Println(outPath)
+func removePartFile(partPath string) {
+	os.Remove(partPath)
+	os.Remove(partValidatorPath(partPath))
+}
+
+// fetchArtifactPart performs a single request, appending to partPath from its
+// current size. The range is requested with If-Range set to validator, which
+// is updated whenever the server starts the file over. Returns the
+// Content-Disposition filename and whether the file is complete.
+func fetchArtifactPart(artifactUuid string, raw bool, artVersion int, partPath string, validator *string, spinner *progressSpinner) (string, bool, error) {
+	var offset int64
+	if fi, err := os.Stat(partPath); err == nil {
+		offset = fi.Size()
+	}
+
+	req, url := newArtifactDownloadRequest(artifactUuid, raw, artVersion)
+	if offset > 0 {
+		req = req.SetHeader("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+		if *validator != "" {
+			req = req.SetHeader("If-Range", *validator)
+		}
+	}
+	resp, err := req.SetDoNotParseResponse(true).Get(url)
+	if err != nil {
+		return "", false, retryableDownloadError{err}
+	}
+	body := resp.RawBody()
+	defer body.Close()
+	cdName := contentDispositionFilename(resp.Header().Get("Content-Disposition"))
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode() {
+	case 200:
+		// Server ignored the range, the artifact changed since the partial
+		// file was written or there is nothing to resume, start over
+		offset = 0
+		flags |= os.O_TRUNC
+		*validator = responseValidator(resp)
+		if *validator != "" {
+			if err := os.WriteFile(partValidatorPath(partPath), []byte(*validator), 0644); err != nil {
+				return cdName, false, err
+			}
+		} else {
+			os.Remove(partValidatorPath(partPath))
+		}
+	case 206:
+		if !strings.HasPrefix(resp.Header().Get("Content-Range"), "bytes "+strconv.FormatInt(offset, 10)+"-") {
+			removePartFile(partPath)
+			return cdName, false, retryableDownloadError{fmt.Errorf("server resumed at unexpected range %q", resp.Header().Get("Content-Range"))}
+		}
+		flags |= os.O_APPEND
+	case 416:
+		// Partial file is at least as large as the artifact, which means it is
+		// stale; drop it and retry from scratch
+		removePartFile(partPath)
+		return cdName, false, retryableDownloadError{fmt.Errorf("server returned status %s", resp.Status())}
+	default:
+		if resp.StatusCode() >= 500 {
+			return cdName, false, retryableDownloadError{fmt.Errorf("server returned status %s", resp.Status())}
+		}
+		return cdName, false, fmt.Errorf("server returned status %s", resp.Status())
+	}
+
+	total := int64(-1)
+	if resp.RawResponse.ContentLength >= 0 {
+		total = offset + resp.RawResponse.ContentLength
+	}
+
+	f, err := os.OpenFile(partPath, flags, 0644)
+	if err != nil {
+		return cdName, false, err
+	}
+	defer f.Close()
+
+	var w io.Writer = f
+	if spinner != nil {
+		w = &progressWriter{w: f, spinner: spinner, done: offset, total: total}
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		return cdName, false, retryableDownloadError{err}
+	}
+	if total >= 0 {
+		if fi, err := f.Stat(); err == nil && fi.Size() < total {
+			return cdName, false, retryableDownloadError{fmt.Errorf("connection closed after %d of %d bytes", fi.Size(), total)}
+		}
+	}
+	return cdName, true, nil
+}
+
+// hashFile computes the size and digests of a completed download.
+func hashFile(path string) (*artifactDownload, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	h256 := sha256.New()
+	h512 := sha512.New()
+	size, err := io.Copy(io.MultiWriter(h256, h512), f)
+	if err != nil {
+		return nil, err
+	}
+	return &artifactDownload{
+		Path:   path,
+		Size:   size,
+		Sha256: hex.EncodeToString(h256.Sum(nil)),
+		Sha512: hex.EncodeToString(h512.Sum(nil)),
+	}, nil
+}
+
+// progressWriter reports the number of bytes written through a spinner.
+type progressWriter struct {
+	w       io.Writer
+	spinner *progressSpinner
+	done    int64
+	total   int64
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.done += int64(n)
+	if p.total > 0 {
+		p.spinner.SetMessage(fmt.Sprintf("Downloaded %s / %s (%d%%)", formatBytes(p.done), formatBytes(p.total), p.done*100/p.total))
+	} else {
+		p.spinner.SetMessage("Downloaded " + formatBytes(p.done))
+	}
+	return n, err
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func newArtifactDownloadRequest(artifactUuid string, raw bool, artVersion int) (*resty.Request, string) {
+	endpoint := "/download"
+	if raw {
+		endpoint = "/rawdownload"
+	}
+	url := rearmUri + "/api/programmatic/v1/artifact/" + artifactUuid + endpoint
+
+	if debug == "true" {
+		fmt.Fprintln(os.Stderr, "Downloading artifact", artifactUuid, "from", url)
+	}
+
+	client := resty.New()
+	applySessionToRestyClient(client)
+	req := client.R().
+		SetHeader("User-Agent", "ReARM CLI").
+		SetHeader("Accept-Encoding", "identity"). // disable compression so Body() is raw bytes
+		SetBasicAuth(apiKeyId, apiKey)
+
+	if artVersion > 0 {
+		req = req.SetQueryParam("version", strconv.Itoa(artVersion))
+	}
+	return req, url
+}
+
+// contentDispositionFilename extracts the filename parameter from a
+// Content-Disposition header value.
+func contentDispositionFilename(cd string) string {
+	for _, part := range strings.Split(cd, ";") {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "filename=") {
+			return strings.Trim(strings.TrimPrefix(part, "filename="), `"`)
+		}
+	}
+	return ""
 }
@@ -53,7 +53,7 @@ value passed is ignored.`,
 		}
 		if instance == "" && instanceURI == "" {
 			fmt.Fprintln(os.Stderr, "either --instance or --instanceuri must be supplied")
-			os.Exit(1)
+			exitCommand(1)
 		}
 		query := `
 			query ($instanceUuid: ID, $instanceUri: String, $namespace: String) {
@@ -101,7 +101,7 @@ ignored.`,
 		}
 		if instance == "" && instanceURI == "" {
 			fmt.Fprintln(os.Stderr, "either --instance or --instanceuri must be supplied")
-			os.Exit(1)
+			exitCommand(1)
 		}
 		query := `
 			mutation ($instanceUuid: ID, $instanceUri: String, $productUuid: ID!, $featureSetUuid: ID!, $namespace: String) {
@@ -183,7 +183,7 @@ function on the product.`,
 		var overrides []map[string]interface{}
 		if err := json.Unmarshal([]byte(overridesJson), &overrides); err != nil {
 			fmt.Fprintln(os.Stderr, "Failed to parse --overrides JSON:", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 		query := `
 			mutation ($productUuid: ID!, $overrides: [VersionFeatureSetOverride!]!) {
@@ -20,7 +20,6 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -50,6 +49,7 @@ var getLatestReleaseCmd = &cobra.Command{
 	Long: `This CLI command would connect to ReARM and would obtain latest release for specified Component and Branch
 			or specified Product and Feature Set.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		applyCachedComponent()
 		getLatestReleaseFunc(debug, rearmUri, component, product, branch, tagKey, tagVal, apiKeyId, apiKey, lifecycle, cdxOutput)
 	},
 }
@@ -60,57 +60,7 @@ func getLatestReleaseFunc(debug string, rearmUri string, component string, produ
 		fmt.Println("Using ReARM at", rearmUri)
 	}
 
-	body := map[string]interface{}{}
-
-	if len(component) > 0 {
-		body["component"] = component
-	}
-
-	if len(product) > 0 {
-		body["product"] = product
-	}
-
-	if len(tagKey) > 0 && len(tagVal) > 0 {
-		body["tags"] = tagKey + "____" + tagVal
-	}
-
-	if len(branch) > 0 {
-		body["branch"] = branch
-	}
-
-	if len(lifecycle) > 0 {
-		body["lifecycle"] = strings.ToUpper(lifecycle)
-	}
-
-	// Add VCS-based component identification parameters
-	if len(vcsUri) > 0 {
-		body["vcsUri"] = vcsUri
-		if len(repoPath) > 0 {
-			body["repoPath"] = repoPath
-		}
-	}
-
-	if len(upToVersion) > 0 {
-		body["upToVersion"] = upToVersion
-	}
-
-	if len(approvalEntries) > 0 || len(approvalStates) > 0 {
-		if len(approvalEntries) != len(approvalStates) {
-			fmt.Println("Error: number of approvalentry and approvalstate arguments must be the same!")
-			os.Exit(1)
-		}
-		var conditionGroup ConditionGroupOnReleaseInput
-		conditionGroup.MatchOperator = approvalMatchOperator
-		var conditions []ConditionOnReleaseInput
-		for i := range approvalEntries {
-			var condition ConditionOnReleaseInput
-			condition.ApprovalEntry = approvalEntries[i]
-			condition.ApprovalState = approvalStates[i]
-			conditions = append(conditions, condition)
-		}
-		conditionGroup.Conditions = conditions
-		body["conditions"] = conditionGroup
-	}
+	body := latestReleaseInput(component, product, branch, tagKey, tagVal, lifecycle, approvalConditions())
 
 	var query string
 	var endpoint string
@@ -133,9 +83,12 @@ func getLatestReleaseFunc(debug string, rearmUri string, component string, produ
 	variables := map[string]interface{}{"GetLatestReleaseInput": body}
 
 	data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
+	if cachedComponentRejected(err, func() { delete(body, "component") }) {
+		data, err = sendGraphQLRequest(query, variables, rearmUri+"/graphql")
+	}
 	if err != nil {
 		printGqlError(err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	if cdxOutput {
@@ -153,6 +106,74 @@ func getLatestReleaseFunc(debug string, rearmUri string, component string, produ
 	return jsonResponse
 }
 
+// approvalConditions builds the approval condition group from the
+// --approvalentry, --approvalstate and --operator flags, or returns nil when
+// no approvals were requested.
+func approvalConditions() *ConditionGroupOnReleaseInput {
+	if len(approvalEntries) == 0 && len(approvalStates) == 0 {
+		return nil
+	}
+	if len(approvalEntries) != len(approvalStates) {
+		fmt.Println("Error: number of approvalentry and approvalstate arguments must be the same!")
+		exitCommand(1)
+	}
+	var conditionGroup ConditionGroupOnReleaseInput
+	conditionGroup.MatchOperator = approvalMatchOperator
+	var conditions []ConditionOnReleaseInput
+	for i := range approvalEntries {
+		var condition ConditionOnReleaseInput
+		condition.ApprovalEntry = approvalEntries[i]
+		condition.ApprovalState = approvalStates[i]
+		conditions = append(conditions, condition)
+	}
+	conditionGroup.Conditions = conditions
+	return &conditionGroup
+}
+
+// latestReleaseInput builds the GetLatestReleaseInput for the given selectors,
+// picking up the VCS and upper bound version flags when they are set.
+func latestReleaseInput(component string, product string, branch string, tagKey string, tagVal string,
+	lifecycle string, conditions *ConditionGroupOnReleaseInput) map[string]interface{} {
+	body := map[string]interface{}{}
+
+	if len(component) > 0 {
+		body["component"] = component
+	}
+
+	if len(product) > 0 {
+		body["product"] = product
+	}
+
+	if len(tagKey) > 0 && len(tagVal) > 0 {
+		body["tags"] = tagKey + "____" + tagVal
+	}
+
+	if len(branch) > 0 {
+		body["branch"] = branch
+	}
+
+	if len(lifecycle) > 0 {
+		body["lifecycle"] = strings.ToUpper(lifecycle)
+	}
+
+	// Add VCS-based component identification parameters
+	if len(vcsUri) > 0 {
+		body["vcsUri"] = vcsUri
+		if len(repoPath) > 0 {
+			body["repoPath"] = repoPath
+		}
+	}
+
+	if len(upToVersion) > 0 {
+		body["upToVersion"] = upToVersion
+	}
+
+	if conditions != nil {
+		body["conditions"] = *conditions
+	}
+	return body
+}
+
 func init() {
 	getLatestReleaseCmd.PersistentFlags().StringVar(&component, "component", "", "Component or Product UUID from ReARM for which to obtain latest release")
 	getLatestReleaseCmd.PersistentFlags().StringVar(&product, "product", "", "Product UUID from ReARM to condition component release to this product (optional)")
@@ -61,7 +61,7 @@ var instDataCmd = &cobra.Command{
 			if err != nil {
 				fmt.Println("Error when reading images file")
 				fmt.Print(err)
-				os.Exit(1)
+				exitCommand(1)
 			}
 			if imageStyle == "k8s" {
 				var k8sjson []map[string]interface{}
@@ -69,7 +69,7 @@ var instDataCmd = &cobra.Command{
 				if errJson != nil {
 					fmt.Println("Error unmarshalling k8s images")
 					fmt.Println(errJson)
-					os.Exit(1)
+					exitCommand(1)
 				}
 				body["type"] = "k8s"
 				body["images"] = k8sjson
@@ -20,7 +20,6 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 
@@ -64,7 +63,7 @@ func retrieveInstancePropsSecrets(props []string, secrs []string) SecretPropsRHR
 		if len(instance) <= 0 && len(instanceURI) <= 0 && !strings.HasPrefix(apiKeyId, "INSTANCE__") && !strings.HasPrefix(apiKeyId, "CLUSTER__") {
 			//throw error and exit
 			fmt.Println("instance or instanceURI not specified!")
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		if len(revision) < 1 {
@@ -106,7 +105,7 @@ func retrieveInstancePropsSecrets(props []string, secrs []string) SecretPropsRHR
 		data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
 		if err != nil {
 			printGqlError(err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		if propSecrets, ok := data["getInstancePropSecrets"].(map[string]interface{}); ok {
@@ -0,0 +1,447 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const lockfileVersion = 1
+
+var (
+	lockComponents    []string
+	lockBranches      []string
+	lockSpec          string
+	lockOutfile       string
+	lockFile          string
+	lockTagSource     string
+	lockTagSourceType string
+	lockRequireLatest bool
+	lockFormat        string
+)
+
+// Lockfile records the releases picked for a set of components deployed
+// together, along with the conditions they were resolved with.
+type Lockfile struct {
+	LockfileVersion int                           `json:"lockfileVersion"`
+	GeneratedAt     string                        `json:"generatedAt"`
+	Lifecycle       string                        `json:"lifecycle,omitempty"`
+	Conditions      *ConditionGroupOnReleaseInput `json:"conditions,omitempty"`
+	Entries         []LockEntry                   `json:"entries"`
+}
+
+// LockEntry is a component and branch (or product and feature set) together
+// with the release resolved for it.
+type LockEntry struct {
+	Component     string              `json:"component"`
+	Branch        string              `json:"branch"`
+	ComponentUuid string              `json:"componentUuid,omitempty"`
+	ComponentName string              `json:"componentName,omitempty"`
+	Release       string              `json:"release,omitempty"`
+	Version       string              `json:"version,omitempty"`
+	Lifecycle     string              `json:"lifecycle,omitempty"`
+	Deliverables  []LockedDeliverable `json:"deliverables,omitempty"`
+}
+
+type LockedDeliverable struct {
+	Uuid              string   `json:"uuid"`
+	DisplayIdentifier string   `json:"displayIdentifier"`
+	Version           string   `json:"version,omitempty"`
+	Digests           []string `json:"digests,omitempty"`
+}
+
+// LockVerification is the machine-readable verdict of lock verify.
+type LockVerification struct {
+	Verdict  string                  `json:"verdict"`
+	Lockfile string                  `json:"lockfile"`
+	Entries  []LockEntryVerification `json:"entries"`
+}
+
+type LockEntryVerification struct {
+	Component string        `json:"component"`
+	Branch    string        `json:"branch"`
+	Release   string        `json:"release"`
+	Version   string        `json:"version"`
+	Checks    []VerifyCheck `json:"checks"`
+}
+
+var lockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Resolve the latest releases of several components into a lockfile",
+	Long: `Resolves the latest release of every --component / --branch pair (or every entry of
+a --spec file) the same way getlatestrelease does, applying the same --lifecycle and
+approval conditions, and writes the picked release UUIDs, versions and deliverable
+digests to a lockfile.
+
+--branch may be given once to use the same branch for every component. For products,
+pass the product as --component and its feature set as --branch.
+
+The spec file is JSON with an "entries" array of {"component", "branch"} objects, so an
+existing lockfile can be passed as --spec to re-lock the same set of components. Its
+lifecycle and approval conditions are reused unless new ones are given.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		spec, err := loadLockSpec()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			exitCommand(2)
+		}
+		lock := Lockfile{
+			LockfileVersion: lockfileVersion,
+			GeneratedAt:     time.Now().UTC().Format(time.RFC3339),
+			Lifecycle:       strings.ToUpper(lifecycle),
+			Conditions:      approvalConditions(),
+			Entries:         []LockEntry{},
+		}
+		// re-locking from an existing lockfile keeps its conditions unless
+		// new ones are given on the command line
+		if lock.Lifecycle == "" && lock.Conditions == nil {
+			lock.Lifecycle = spec.Lifecycle
+			lock.Conditions = spec.Conditions
+		}
+		failed := false
+		for _, e := range spec.Entries {
+			rlz, err := resolveLatestRelease(latestReleaseInput(e.Component, "", e.Branch, "", "", lock.Lifecycle, lock.Conditions))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error resolving %s on %s: %v\n", e.Component, e.Branch, err)
+				failed = true
+				continue
+			}
+			if rlz == nil {
+				fmt.Fprintf(os.Stderr, "Error: no release of %s on %s matches the conditions\n", e.Component, e.Branch)
+				failed = true
+				continue
+			}
+			lock.Entries = append(lock.Entries, lockEntryFromRelease(e.Component, e.Branch, rlz))
+		}
+		if failed {
+			exitCommand(1)
+		}
+
+		if lockOutfile == "-" {
+			emitJson(lock)
+			return
+		}
+		out, _ := json.MarshalIndent(lock, "", "  ")
+		if err := os.WriteFile(lockOutfile, append(out, '\n'), 0644); err != nil {
+			fmt.Fprintln(os.Stderr, "Error writing lockfile:", err)
+			exitCommand(1)
+		}
+		for _, e := range lock.Entries {
+			fmt.Printf("Locked %s (%s) on %s to %s (%s)\n", e.ComponentName, e.ComponentUuid, e.Branch, e.Version, e.Release)
+		}
+		fmt.Println("Wrote", lockOutfile)
+	},
+}
+
+var lockVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Re-check a lockfile against current ReARM state",
+	Long: `Re-checks every entry of a lockfile produced by rearm lock:
+
+  release     the locked release still exists with the locked version
+  conditions  the locked release still satisfies the lifecycle and approval conditions
+              the lockfile was created with
+  digests     every locked deliverable is still on the release with its locked digests
+  latest      no newer release matches the conditions (only fails with --require-latest)
+
+With --tagsource, every locked deliverable found in the tag source file (cyclonedx or
+text, as used by replacetags) must be pinned to one of its locked digests. Images in the
+tag source that are not in the lockfile are ignored.
+
+The verdict is printed as JSON (or text with --format text) and the command exits
+with code 1 when any check fails.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		raw, err := os.ReadFile(lockFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error reading lockfile:", err)
+			exitCommand(1)
+		}
+		var lock Lockfile
+		if err := json.Unmarshal(raw, &lock); err != nil {
+			fmt.Fprintln(os.Stderr, "Error parsing lockfile:", err)
+			exitCommand(1)
+		}
+		var tagSourceMap map[string]string
+		if lockTagSource != "" {
+			tagSourceMap = scanTagFile(lockTagSource, lockTagSourceType)
+		}
+
+		result := LockVerification{Verdict: verdictPass, Lockfile: lockFile, Entries: []LockEntryVerification{}}
+		for _, e := range lock.Entries {
+			v := LockEntryVerification{Component: e.ComponentName, Branch: e.Branch, Release: e.Release, Version: e.Version}
+			if v.Component == "" {
+				v.Component = e.Component
+			}
+			v.Checks = verifyLockEntry(&lock, e)
+			if tagSourceMap != nil {
+				v.Checks = append(v.Checks, verifyLockTagSource(e, tagSourceMap)...)
+			}
+			for _, c := range v.Checks {
+				if !c.Passed {
+					result.Verdict = verdictFail
+				}
+			}
+			result.Entries = append(result.Entries, v)
+		}
+
+		if lockFormat == "text" {
+			printLockVerification(result)
+		} else {
+			emitJson(result)
+		}
+		if result.Verdict != verdictPass {
+			exitCommand(1)
+		}
+	},
+}
+
+// loadLockSpec returns the component / branch pairs to lock from either
+// --spec or the --component and --branch flags.
+func loadLockSpec() (*Lockfile, error) {
+	if lockSpec != "" {
+		if len(lockComponents) > 0 {
+			return nil, fmt.Errorf("--spec and --component are mutually exclusive")
+		}
+		raw, err := os.ReadFile(lockSpec)
+		if err != nil {
+			return nil, err
+		}
+		var spec Lockfile
+		if err := json.Unmarshal(raw, &spec); err != nil {
+			return nil, fmt.Errorf("parsing %s: %v", lockSpec, err)
+		}
+		if len(spec.Entries) == 0 {
+			return nil, fmt.Errorf("%s has no entries", lockSpec)
+		}
+		for _, e := range spec.Entries {
+			if e.Component == "" || e.Branch == "" {
+				return nil, fmt.Errorf("every entry of %s needs a component and a branch", lockSpec)
+			}
+		}
+		return &spec, nil
+	}
+	if len(lockComponents) == 0 {
+		return nil, fmt.Errorf("either --spec or at least one --component must be specified")
+	}
+	if len(lockBranches) != 1 && len(lockBranches) != len(lockComponents) {
+		return nil, fmt.Errorf("--branch must be given once or once per --component")
+	}
+	spec := Lockfile{}
+	for i, c := range lockComponents {
+		b := lockBranches[0]
+		if len(lockBranches) > 1 {
+			b = lockBranches[i]
+		}
+		spec.Entries = append(spec.Entries, LockEntry{Component: c, Branch: b})
+	}
+	return &spec, nil
+}
+
+// resolveLatestRelease runs getLatestReleaseProgrammatic with the given input
+// and returns the release content, or nil when no release matches.
+func resolveLatestRelease(input map[string]interface{}) (*releaseContent, error) {
+	query := `
+		query ($GetLatestReleaseInput: GetLatestReleaseInput!) {
+			getLatestReleaseProgrammatic(release:$GetLatestReleaseInput) {` + RELEASE_CONTENT_GQL_DATA + `}
+		}
+	`
+	variables := map[string]interface{}{"GetLatestReleaseInput": input}
+	data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
+	if err != nil {
+		return nil, err
+	}
+	if data["getLatestReleaseProgrammatic"] == nil {
+		return nil, nil
+	}
+	var rlz releaseContent
+	if err := decodeInto(data["getLatestReleaseProgrammatic"], &rlz); err != nil {
+		return nil, err
+	}
+	return &rlz, nil
+}
+
+func lockEntryFromRelease(component string, branch string, rlz *releaseContent) LockEntry {
+	entry := LockEntry{
+		Component:     component,
+		Branch:        branch,
+		ComponentUuid: rlz.ComponentDetails.Uuid,
+		ComponentName: rlz.ComponentDetails.Name,
+		Release:       rlz.Uuid,
+		Version:       rlz.Version,
+		Lifecycle:     rlz.Lifecycle,
+		Deliverables:  []LockedDeliverable{},
+	}
+	for _, d := range rlz.deliverables() {
+		entry.Deliverables = append(entry.Deliverables, LockedDeliverable{
+			Uuid:              d.Uuid,
+			DisplayIdentifier: d.DisplayIdentifier,
+			Version:           d.Version,
+			Digests:           d.SoftwareMetadata.Digests,
+		})
+	}
+	return entry
+}
+
+// verifyLockEntry checks a locked release against its current state in
+// ReARM. The conditions check asks the backend for the latest matching
+// release up to the locked version, which is the locked release itself as
+// long as it still satisfies the conditions.
+func verifyLockEntry(lock *Lockfile, e LockEntry) []VerifyCheck {
+	var rlz releaseContent
+	if err := fetchReleaseContent(e.Release, "", &rlz); err != nil {
+		return []VerifyCheck{{Name: "release", Passed: false, Detail: err.Error()}}
+	}
+	checks := []VerifyCheck{}
+	release := VerifyCheck{Name: "release", Passed: true, Detail: rlz.Lifecycle}
+	if rlz.Version != e.Version {
+		release.Passed = false
+		release.Detail = "release version is " + rlz.Version + ", locked " + e.Version
+	}
+	checks = append(checks, release)
+
+	input := latestReleaseInput(e.Component, "", e.Branch, "", "", lock.Lifecycle, lock.Conditions)
+	input["upToVersion"] = e.Version
+	conditions := VerifyCheck{Name: "conditions", Passed: true}
+	if matching, err := resolveLatestRelease(input); err != nil {
+		conditions.Passed = false
+		conditions.Detail = err.Error()
+	} else if matching == nil || matching.Uuid != e.Release {
+		conditions.Passed = false
+		conditions.Detail = "locked release no longer satisfies the lock conditions"
+		if matching != nil {
+			conditions.Detail += ", latest matching up to " + e.Version + " is " + matching.Version
+		}
+	}
+	checks = append(checks, conditions)
+
+	current := make(map[string]releaseDeliverable)
+	for _, d := range rlz.deliverables() {
+		current[d.Uuid] = d
+	}
+	digests := VerifyCheck{Name: "digests", Passed: true}
+	var drift []string
+	for _, ld := range e.Deliverables {
+		d, ok := current[ld.Uuid]
+		if !ok {
+			drift = append(drift, ld.DisplayIdentifier+" is no longer on the release")
+			continue
+		}
+		for _, dg := range ld.Digests {
+			if !contains(d.SoftwareMetadata.Digests, dg) {
+				drift = append(drift, ld.DisplayIdentifier+" no longer has "+dg)
+			}
+		}
+	}
+	if len(drift) > 0 {
+		digests.Passed = false
+		digests.Detail = strings.Join(drift, "; ")
+	}
+	checks = append(checks, digests)
+
+	latest := VerifyCheck{Name: "latest", Passed: true}
+	if newest, err := resolveLatestRelease(latestReleaseInput(e.Component, "", e.Branch, "", "", lock.Lifecycle, lock.Conditions)); err != nil {
+		latest.Passed = !lockRequireLatest
+		latest.Detail = err.Error()
+	} else if newest != nil && newest.Uuid != e.Release {
+		latest.Passed = !lockRequireLatest
+		latest.Detail = "newer release " + newest.Version + " (" + newest.Uuid + ") is available"
+	}
+	checks = append(checks, latest)
+	return checks
+}
+
+// verifyLockTagSource checks that every locked deliverable present in the
+// tag source is pinned to one of its locked digests.
+func verifyLockTagSource(e LockEntry, tagSourceMap map[string]string) []VerifyCheck {
+	checks := []VerifyCheck{}
+	for _, ld := range e.Deliverables {
+		image := stripImageHashTag(ld.DisplayIdentifier)
+		tagged, ok := tagSourceMap[image]
+		if !ok {
+			continue
+		}
+		c := VerifyCheck{Name: "tagsource:" + image, Passed: false}
+		subst := GetSubstitutionFromDigestedString(tagged)
+		if subst.Digest == "" {
+			c.Detail = "tag source does not pin a digest for " + image
+			checks = append(checks, c)
+			continue
+		}
+		_, pinned, err := parseDigestArg(subst.Digest)
+		if err != nil {
+			c.Detail = err.Error()
+			checks = append(checks, c)
+			continue
+		}
+		for _, dg := range ld.Digests {
+			if _, locked, err := parseDigestArg(dg); err == nil && locked == pinned {
+				c.Passed = true
+				break
+			}
+		}
+		if !c.Passed {
+			c.Detail = "tag source pins " + subst.Digest + " which is not a locked digest"
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+func printLockVerification(r LockVerification) {
+	fmt.Printf("%s %s\n", r.Verdict, r.Lockfile)
+	for _, e := range r.Entries {
+		fmt.Printf("  %s %s on %s (%s)\n", e.Component, e.Version, e.Branch, e.Release)
+		for _, c := range e.Checks {
+			status := "ok"
+			if !c.Passed {
+				status = "FAILED"
+			}
+			if c.Detail != "" {
+				fmt.Printf("    %-10s %s: %s\n", c.Name, status, c.Detail)
+			} else {
+				fmt.Printf("    %-10s %s\n", c.Name, status)
+			}
+		}
+	}
+}
+
+func init() {
+	lockCmd.Flags().StringArrayVar(&lockComponents, "component", []string{}, "Component or Product UUID to lock (multiple allowed)")
+	lockCmd.Flags().StringArrayVarP(&lockBranches, "branch", "b", []string{}, "Branch (or Feature Set for products) of each --component, or a single branch for all of them")
+	lockCmd.Flags().StringVar(&lockSpec, "spec", "", "JSON file with the entries to lock, instead of --component and --branch")
+	lockCmd.Flags().StringVar(&lockOutfile, "outfile", "rearm.lock.json", "Path of the lockfile to write, or - for stdout")
+	lockCmd.Flags().StringVar(&lifecycle, "lifecycle", "", "Lifecycle filter for the locked releases (optional), same as for getlatestrelease")
+	lockCmd.Flags().StringVar(&approvalMatchOperator, "operator", "AND", "Match operator for a list of approvals, 'AND' or 'OR' default is 'AND' (optional)")
+	lockCmd.Flags().StringSliceVar(&approvalEntries, "approvalentry", []string{}, "Approval entry names or ids (optional, multiple allowed)")
+	lockCmd.Flags().StringSliceVar(&approvalStates, "approvalstate", []string{}, "Approval states corresponding to approval entries, can be 'APPROVED', 'DISAPPROVED' or 'UNSET' (optional, multiple allowed, required if approval entries are present)")
+
+	lockVerifyCmd.PersistentFlags().StringVar(&lockFile, "lockfile", "rearm.lock.json", "Path of the lockfile to verify")
+	lockVerifyCmd.PersistentFlags().StringVar(&lockTagSource, "tagsource", "", "Tag source file to check against the locked digests (optional)")
+	lockVerifyCmd.PersistentFlags().StringVar(&lockTagSourceType, "type", "cyclonedx", "Type of the tag source file: cyclonedx (default) or text")
+	lockVerifyCmd.PersistentFlags().BoolVar(&lockRequireLatest, "require-latest", false, "Fail when a newer release matching the lock conditions is available")
+	lockVerifyCmd.PersistentFlags().StringVar(&lockFormat, "format", "json", "Output format: json or text")
+
+	lockCmd.AddCommand(lockVerifyCmd)
+	rootCmd.AddCommand(lockCmd)
+}
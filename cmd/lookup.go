@@ -0,0 +1,240 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	lookupOrg         string
+	lookupFile        string
+	lookupConcurrency int
+	lookupFormat      string
+)
+
+const searchDigestVersionQuery = `
+	query ($orgUuid: ID!, $query: String) {
+		searchDigestVersion(orgUuid: $orgUuid, query: $query) {
+			commitReleases {
+				uuid
+				version
+				lifecycle
+				component
+				componentDetails {
+					name
+				}
+				branch
+				branchDetails {
+					name
+				}
+			}
+		}
+	}
+`
+
+// DigestMatch is a release whose deliverables carry the looked up digest.
+type DigestMatch struct {
+	Release       string `json:"release"`
+	Component     string `json:"component"`
+	ComponentName string `json:"componentName"`
+	Branch        string `json:"branch"`
+	BranchName    string `json:"branchName"`
+	Version       string `json:"version"`
+	Lifecycle     string `json:"lifecycle"`
+}
+
+type DigestLookupResult struct {
+	Digest  string        `json:"digest"`
+	Matches []DigestMatch `json:"matches"`
+	Error   string        `json:"error,omitempty"`
+}
+
+var lookupCmd = &cobra.Command{
+	Use:   "lookup",
+	Short: "Bulk lookups against ReARM",
+	Long:  `Set of commands to resolve identifiers such as image digests to ReARM releases in bulk.`,
+}
+
+var lookupDigestsCmd = &cobra.Command{
+	Use:   "digests",
+	Short: "Resolve a list of digests to releases organization-wide",
+	Long: `Reads digests one per line from --file (use - for stdin) and resolves each of them
+to the releases that carry it across the whole organization. Lines may also be full image
+references such as registry/image@sha256:..., in which case only the digest part is used.
+Blank lines and lines starting with # are ignored.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if lookupFormat != "text" && lookupFormat != "json" {
+			fmt.Fprintln(os.Stderr, "Error: --format must be text or json")
+			exitCommand(2)
+		}
+		if lookupConcurrency < 1 {
+			fmt.Fprintln(os.Stderr, "Error: --concurrency must be at least 1")
+			exitCommand(2)
+		}
+		digests, err := readDigestList(lookupFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error reading digests:", err)
+			exitCommand(1)
+		}
+		if debug == "true" {
+			fmt.Fprintln(os.Stderr, "Using ReARM at", rearmUri)
+			fmt.Fprintln(os.Stderr, "Looking up", len(digests), "digests")
+		}
+
+		results := lookupDigests(lookupOrg, digests, lookupConcurrency)
+
+		if lookupFormat == "json" {
+			emitJson(results)
+		} else {
+			printDigestLookup(results)
+		}
+		for _, r := range results {
+			if r.Error != "" {
+				exitCommand(1)
+			}
+		}
+	},
+}
+
+// readDigestList reads one digest per line, deduplicating while keeping the
+// original order.
+func readDigestList(path string) ([]string, error) {
+	var in io.Reader
+	if path == "" || path == "-" {
+		in = os.Stdin
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		in = f
+	}
+	var digests []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.LastIndex(line, "@"); i >= 0 {
+			line = line[i+1:]
+		}
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+		digests = append(digests, line)
+	}
+	return digests, scanner.Err()
+}
+
+// lookupDigests resolves digests with at most concurrency requests in flight.
+// Results are returned in input order.
+func lookupDigests(org string, digests []string, concurrency int) []DigestLookupResult {
+	results := make([]DigestLookupResult, len(digests))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, d := range digests {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, d string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = lookupDigest(org, d)
+		}(i, d)
+	}
+	wg.Wait()
+	return results
+}
+
+func lookupDigest(org string, digest string) DigestLookupResult {
+	result := DigestLookupResult{Digest: digest, Matches: []DigestMatch{}}
+	variables := map[string]interface{}{"orgUuid": org, "query": digest}
+	data, err := sendGraphQLRequest(searchDigestVersionQuery, variables, rearmUri+"/graphql")
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	var resp struct {
+		CommitReleases []struct {
+			releaseSummary
+			Lifecycle     string `json:"lifecycle"`
+			Branch        string `json:"branch"`
+			BranchDetails struct {
+				Name string `json:"name"`
+			} `json:"branchDetails"`
+		} `json:"commitReleases"`
+	}
+	if err := decodeInto(data["searchDigestVersion"], &resp); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	for _, r := range resp.CommitReleases {
+		result.Matches = append(result.Matches, DigestMatch{
+			Release:       r.Uuid,
+			Component:     r.Component,
+			ComponentName: r.ComponentDetails.Name,
+			Branch:        r.Branch,
+			BranchName:    r.BranchDetails.Name,
+			Version:       r.Version,
+			Lifecycle:     r.Lifecycle,
+		})
+	}
+	return result
+}
+
+func printDigestLookup(results []DigestLookupResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DIGEST\tCOMPONENT\tBRANCH\tVERSION\tLIFECYCLE\tRELEASE")
+	for _, r := range results {
+		switch {
+		case r.Error != "":
+			fmt.Fprintf(w, "%s\tERROR: %s\t\t\t\t\n", r.Digest, r.Error)
+		case len(r.Matches) == 0:
+			fmt.Fprintf(w, "%s\t(not found)\t\t\t\t\n", r.Digest)
+		default:
+			for _, m := range r.Matches {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", r.Digest, m.ComponentName, m.BranchName, m.Version, m.Lifecycle, m.Release)
+			}
+		}
+	}
+	w.Flush()
+}
+
+func init() {
+	lookupDigestsCmd.PersistentFlags().StringVar(&lookupOrg, "org", "", "UUID of the organization to search in")
+	lookupDigestsCmd.PersistentFlags().StringVar(&lookupFile, "file", "", "File with one digest or image reference per line (- for stdin)")
+	lookupDigestsCmd.PersistentFlags().IntVar(&lookupConcurrency, "concurrency", 8, "Maximum number of lookups in flight")
+	lookupDigestsCmd.PersistentFlags().StringVar(&lookupFormat, "format", "text", "Output format: text or json")
+	lookupDigestsCmd.MarkPersistentFlagRequired("org")
+	lookupDigestsCmd.MarkPersistentFlagRequired("file")
+
+	lookupCmd.AddCommand(lookupDigestsCmd)
+	rootCmd.AddCommand(lookupCmd)
+}
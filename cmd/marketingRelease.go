@@ -0,0 +1,318 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	mrUuid             string
+	mrComponent        string
+	mrOrg              string
+	mrVersion          string
+	mrLifecycle        string
+	mrIntegrateType    string
+	mrIntegrateBranch  string
+	mrDevRelease       string
+	mrNotes            string
+	mrTags             []string
+	mrMarketingVersion string
+)
+
+const MARKETING_RELEASE_GQL_DATA = `
+	uuid
+	version
+	status
+	org
+	component
+	componentDetails {
+		uuid
+		name
+	}
+	notes
+	tags {
+		key
+		value
+	}
+	lifecycle
+	integrateType
+	integrateBranch
+	devReleasePointer
+	createdDate
+	events {
+		release
+		lifecycle
+		date
+	}
+`
+
+var marketingReleaseLifecycles = []string{"FIRST_MENTION", "ALPHA_TESTING", "BETA_TESTING", "RELEASE_CANDIDATE", "GENERAL_AVAILABILITY", "END_OF_LIFE"}
+
+var marketingReleaseLifecycleAliases = map[string]string{
+	"ALPHA": "ALPHA_TESTING",
+	"BETA":  "BETA_TESTING",
+	"RC":    "RELEASE_CANDIDATE",
+	"GA":    "GENERAL_AVAILABILITY",
+	"EOL":   "END_OF_LIFE",
+}
+
+var marketingReleaseCmd = &cobra.Command{
+	Use:   "marketing-release",
+	Short: "Manage customer-facing marketing releases of a component",
+	Long: `Set of commands to create, list, advance and release marketing releases, the
+customer-facing versions that point to a development release of a component.`,
+}
+
+var marketingReleaseCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a marketing release for a component",
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM at", rearmUri)
+		}
+		input := map[string]interface{}{"component": mrComponent, "version": mrVersion}
+		if len(mrLifecycle) > 0 {
+			input["lifecycle"] = marketingReleaseLifecycle(mrLifecycle)
+		}
+		org := mrOrg
+		if len(org) == 0 {
+			data, err := sendGraphQLRequest(`
+				query ($componentUuid: ID!) {
+					component(componentUuid: $componentUuid) { org }
+				}
+			`, map[string]interface{}{"componentUuid": mrComponent}, rearmUri+"/graphql")
+			if err != nil {
+				printGqlError(err)
+				exitCommand(1)
+			}
+			comp, ok := data["component"].(map[string]interface{})
+			if !ok {
+				fmt.Fprintln(os.Stderr, "Error: component", mrComponent, "not found")
+				exitCommand(1)
+			}
+			org, _ = comp["org"].(string)
+		}
+		input["org"] = org
+		if len(mrIntegrateType) > 0 {
+			it := strings.ToUpper(mrIntegrateType)
+			if it != "FOLLOW" && it != "TARGET" {
+				fmt.Fprintln(os.Stderr, "Error: --integrate-type must be FOLLOW or TARGET")
+				exitCommand(2)
+			}
+			input["integrateType"] = it
+		}
+		if len(mrIntegrateBranch) > 0 {
+			input["integrateBranch"] = mrIntegrateBranch
+		}
+		if len(mrDevRelease) > 0 {
+			input["devReleasePointer"] = mrDevRelease
+		}
+		if len(mrNotes) > 0 {
+			input["notes"] = mrNotes
+		}
+		if len(mrTags) > 0 {
+			var tags []TagInput
+			for _, t := range mrTags {
+				key, value, ok := strings.Cut(t, "=")
+				if !ok || key == "" {
+					fmt.Fprintf(os.Stderr, "Error: --tag must be in key=value form, got %q\n", t)
+					exitCommand(2)
+				}
+				tags = append(tags, TagInput{Key: key, Value: value})
+			}
+			input["tags"] = tags
+		}
+		query := `
+			mutation ($marketingRelease: MarketingReleaseInput!) {
+				addMarketingReleaseManual(marketingRelease: $marketingRelease) {` + MARKETING_RELEASE_GQL_DATA + `}
+			}
+		`
+		fmt.Println(sendRequest(query, map[string]interface{}{"marketingRelease": input}, "addMarketingReleaseManual"))
+	},
+}
+
+var marketingReleaseListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List marketing releases of a component",
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM at", rearmUri)
+		}
+		query := `
+			query ($componentUuid: ID!) {
+				marketingReleases(componentUuid: $componentUuid) {` + MARKETING_RELEASE_GQL_DATA + `}
+			}
+		`
+		fmt.Println(sendRequest(query, map[string]interface{}{"componentUuid": mrComponent}, "marketingReleases"))
+	},
+}
+
+var marketingReleaseAdvanceCmd = &cobra.Command{
+	Use:   "advance",
+	Short: "Move a marketing release to another lifecycle",
+	Long: `Moves a marketing release to --lifecycle, one of FIRST_MENTION, ALPHA_TESTING,
+BETA_TESTING, RELEASE_CANDIDATE, GENERAL_AVAILABILITY or END_OF_LIFE (aliases ALPHA, BETA,
+RC, GA and EOL are accepted). The marketing release is identified by --marketing-release
+or by --component and --version.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM at", rearmUri)
+		}
+		lifecycle := marketingReleaseLifecycle(mrLifecycle)
+		mr := resolveMarketingRelease()
+		query := `
+			mutation ($marketingReleaseUuid: ID!, $newLifecycle: MarketingReleaseLifecycleEnum) {
+				advanceMarketingReleaseLifecycle(marketingReleaseUuid: $marketingReleaseUuid, newLifecycle: $newLifecycle) {` + MARKETING_RELEASE_GQL_DATA + `}
+			}
+		`
+		variables := map[string]interface{}{"marketingReleaseUuid": mr["uuid"], "newLifecycle": lifecycle}
+		fmt.Println(sendRequest(query, variables, "advanceMarketingReleaseLifecycle"))
+	},
+}
+
+var marketingReleaseReleaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Release a marketing release under its customer-facing version",
+	Long: `Releases a marketing release with --marketing-version as the customer-facing version.
+--dev-release optionally points it to another development release first. The marketing
+release is identified by --marketing-release or by --component and --version.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM at", rearmUri)
+		}
+		mr := resolveMarketingRelease()
+		input := map[string]interface{}{
+			"uuid":      mr["uuid"],
+			"org":       mr["org"],
+			"component": mr["component"],
+			"version":   mr["version"],
+		}
+		if len(mrDevRelease) > 0 {
+			input["devReleasePointer"] = mrDevRelease
+		}
+		query := `
+			mutation ($marketingRelease: MarketingReleaseInput!, $marketingVersion: String!) {
+				releaseMarketingRelease(marketingRelease: $marketingRelease, marketingVersion: $marketingVersion) {` + MARKETING_RELEASE_GQL_DATA + `}
+			}
+		`
+		variables := map[string]interface{}{"marketingRelease": input, "marketingVersion": mrMarketingVersion}
+		fmt.Println(sendRequest(query, variables, "releaseMarketingRelease"))
+	},
+}
+
+// marketingReleaseLifecycle validates a lifecycle flag and resolves aliases.
+// Exits with code 2 on unknown values.
+func marketingReleaseLifecycle(lifecycle string) string {
+	lc := strings.ToUpper(lifecycle)
+	if alias, ok := marketingReleaseLifecycleAliases[lc]; ok {
+		lc = alias
+	}
+	for _, known := range marketingReleaseLifecycles {
+		if lc == known {
+			return lc
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Error: --lifecycle must be one of %s\n", strings.Join(marketingReleaseLifecycles, ", "))
+	exitCommand(2)
+	return ""
+}
+
+// resolveMarketingRelease loads the marketing release selected by
+// --marketing-release, or by --component and --version.
+func resolveMarketingRelease() map[string]interface{} {
+	if len(mrUuid) > 0 {
+		data, err := sendGraphQLRequest(`
+			query ($marketingReleaseUuid: ID!) {
+				marketingRelease(marketingReleaseUuid: $marketingReleaseUuid) {`+MARKETING_RELEASE_GQL_DATA+`}
+			}
+		`, map[string]interface{}{"marketingReleaseUuid": mrUuid}, rearmUri+"/graphql")
+		if err != nil {
+			printGqlError(err)
+			exitCommand(1)
+		}
+		mr, ok := data["marketingRelease"].(map[string]interface{})
+		if !ok {
+			fmt.Fprintln(os.Stderr, "Error: marketing release", mrUuid, "not found")
+			exitCommand(1)
+		}
+		return mr
+	}
+	if len(mrComponent) == 0 || len(mrVersion) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: either --marketing-release or --component and --version must be set")
+		exitCommand(2)
+	}
+	data, err := sendGraphQLRequest(`
+		query ($componentUuid: ID!) {
+			marketingReleases(componentUuid: $componentUuid) {`+MARKETING_RELEASE_GQL_DATA+`}
+		}
+	`, map[string]interface{}{"componentUuid": mrComponent}, rearmUri+"/graphql")
+	if err != nil {
+		printGqlError(err)
+		exitCommand(1)
+	}
+	list, _ := data["marketingReleases"].([]interface{})
+	for _, item := range list {
+		if mr, ok := item.(map[string]interface{}); ok && mr["version"] == mrVersion {
+			return mr
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Error: marketing release %s of component %s not found\n", mrVersion, mrComponent)
+	exitCommand(1)
+	return nil
+}
+
+func init() {
+	marketingReleaseCreateCmd.PersistentFlags().StringVar(&mrComponent, "component", "", "UUID of the component or product")
+	marketingReleaseCreateCmd.PersistentFlags().StringVar(&mrVersion, "version", "", "Version of the marketing release")
+	marketingReleaseCreateCmd.PersistentFlags().StringVar(&mrOrg, "org", "", "(Optional) UUID of the organization, defaults to the organization of the component")
+	marketingReleaseCreateCmd.PersistentFlags().StringVar(&mrLifecycle, "lifecycle", "", "(Optional) Initial lifecycle, e.g. FIRST_MENTION")
+	marketingReleaseCreateCmd.PersistentFlags().StringVar(&mrIntegrateType, "integrate-type", "", "(Optional) FOLLOW or TARGET")
+	marketingReleaseCreateCmd.PersistentFlags().StringVar(&mrIntegrateBranch, "integrate-branch", "", "(Optional) UUID of the branch to integrate from")
+	marketingReleaseCreateCmd.PersistentFlags().StringVar(&mrDevRelease, "dev-release", "", "(Optional) UUID of the development release this marketing release points to")
+	marketingReleaseCreateCmd.PersistentFlags().StringVar(&mrNotes, "notes", "", "(Optional) Notes")
+	marketingReleaseCreateCmd.PersistentFlags().StringArrayVar(&mrTags, "tag", []string{}, "(Optional) Tag in key=value form, repeatable")
+	marketingReleaseCreateCmd.MarkPersistentFlagRequired("component")
+	marketingReleaseCreateCmd.MarkPersistentFlagRequired("version")
+
+	marketingReleaseListCmd.PersistentFlags().StringVar(&mrComponent, "component", "", "UUID of the component or product")
+	marketingReleaseListCmd.MarkPersistentFlagRequired("component")
+
+	marketingReleaseAdvanceCmd.PersistentFlags().StringVar(&mrUuid, "marketing-release", "", "UUID of the marketing release")
+	marketingReleaseAdvanceCmd.PersistentFlags().StringVar(&mrComponent, "component", "", "UUID of the component, used with --version instead of --marketing-release")
+	marketingReleaseAdvanceCmd.PersistentFlags().StringVar(&mrVersion, "version", "", "Version of the marketing release, used with --component")
+	marketingReleaseAdvanceCmd.PersistentFlags().StringVar(&mrLifecycle, "lifecycle", "", "New lifecycle")
+	marketingReleaseAdvanceCmd.MarkPersistentFlagRequired("lifecycle")
+
+	marketingReleaseReleaseCmd.PersistentFlags().StringVar(&mrUuid, "marketing-release", "", "UUID of the marketing release")
+	marketingReleaseReleaseCmd.PersistentFlags().StringVar(&mrComponent, "component", "", "UUID of the component, used with --version instead of --marketing-release")
+	marketingReleaseReleaseCmd.PersistentFlags().StringVar(&mrVersion, "version", "", "Version of the marketing release, used with --component")
+	marketingReleaseReleaseCmd.PersistentFlags().StringVar(&mrMarketingVersion, "marketing-version", "", "Customer-facing version to release under")
+	marketingReleaseReleaseCmd.PersistentFlags().StringVar(&mrDevRelease, "dev-release", "", "(Optional) UUID of the development release to point to")
+	marketingReleaseReleaseCmd.MarkPersistentFlagRequired("marketing-version")
+
+	marketingReleaseCmd.AddCommand(marketingReleaseCreateCmd)
+	marketingReleaseCmd.AddCommand(marketingReleaseListCmd)
+	marketingReleaseCmd.AddCommand(marketingReleaseAdvanceCmd)
+	marketingReleaseCmd.AddCommand(marketingReleaseReleaseCmd)
+	rootCmd.AddCommand(marketingReleaseCmd)
+}
@@ -32,15 +32,15 @@ var mergeBomsCmd = &cobra.Command{
 		// Validate required flags
 		if len(MergeInputFiles) == 0 {
 			fmt.Fprintln(os.Stderr, "Error: --input-files is required")
-			os.Exit(1)
+			exitCommand(1)
 		}
 		if MergeName == "" {
 			fmt.Fprintln(os.Stderr, "Error: --name is required")
-			os.Exit(1)
+			exitCommand(1)
 		}
 		if MergeVersion == "" {
 			fmt.Fprintln(os.Stderr, "Error: --version is required")
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// 1. Read all BOM objects from input files
@@ -49,142 +49,34 @@ var mergeBomsCmd = &cobra.Command{
 			data, err := os.ReadFile(path)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error reading file %s: %v\n", path, err)
-				os.Exit(1)
+				exitCommand(1)
 			}
 			bom := readBomFromBytes(data)
 			boms = append(boms, bom)
 		}
 
-		// 2. Extract roots, components, dependencies
-		var roots []*cdx.Component
-		var allComponents []*cdx.Component
-		var allDependencies []*cdx.Dependency
-
-		for _, bom := range boms {
-			if bom.Metadata != nil && bom.Metadata.Component != nil {
-				roots = append(roots, bom.Metadata.Component)
-			}
-			if bom.Components != nil {
-				for _, comp := range *bom.Components {
-					allComponents = append(allComponents, &comp)
-				}
-			}
-			if bom.Dependencies != nil {
-				for _, dep := range *bom.Dependencies {
-					allDependencies = append(allDependencies, &dep)
-				}
-			}
-		}
-
-		// Prepare deduplication map for FLAT mode
-		componentMap := make(map[string]*cdx.Component) // key: purl or bom-ref
-
-		// Add root components to the map first (they may be referenced in dependencies)
-		for _, root := range roots {
-			if root != nil {
-				key := root.PackageURL
-				if key == "" && root.BOMRef != "" {
-					key = root.BOMRef
-				}
-				if key != "" {
-					componentMap[key] = root
-				}
-			}
-		}
-
-		droppedCount := 0
-		for _, comp := range allComponents {
-			// Prefer PackageURL over BOMRef for consistent deduplication
-			key := comp.PackageURL
-			if key == "" && comp.BOMRef != "" {
-				key = comp.BOMRef
-			}
-			if key != "" {
-				if existing, exists := componentMap[key]; exists {
-					// Merge metadata from duplicate component
-					mergeComponentMetadata(existing, comp)
-				} else {
-					componentMap[key] = comp
-				}
-			} else {
-				// Warn about components without identifiers
-				droppedCount++
-				compName := comp.Name
-				if compName == "" {
-					compName = unnamedComponentPlaceholder
-				}
-				fmt.Fprintf(os.Stderr, "Warning: Dropping component '%s' (type: %s) - missing both PackageURL and BOMRef\n",
-					compName, comp.Type)
-			}
-		}
-		if droppedCount > 0 {
-			fmt.Fprintf(os.Stderr, "Warning: Dropped %d component(s) without identifiers\n", droppedCount)
-		}
-
-		// 3. Merge logic
-		// 3.1 Metadata: create new BOM object, set root from flags
-		mergedBOM := &cdx.BOM{
-			BOMFormat:    "CycloneDX",
-			SpecVersion:  cdx.SpecVersion1_6,
-			SerialNumber: generateSerialNumber(),
-			Version:      1,
-			Metadata: &cdx.Metadata{
-				Component: &cdx.Component{
-					Type:    cdx.ComponentTypeApplication,
-					Name:    MergeName,
-					Group:   MergeGroup,
-					Version: MergeVersion,
-				},
-			},
-		}
-
-		// Set merged root component BOMRef and PackageURL
-		mergedRootRef := setMergedRootComponent(
-			mergedBOM.Metadata.Component,
-			MergeGroup, MergeName, MergeVersion, MergePurl,
-		)
-
-		// 3.2 Components: FLAT or HIERARCHICAL
-		if MergeStructure == "HIERARCHICAL" {
-			hierComponents := mergeHierarchicalComponents(roots, boms)
-			mergedBOM.Components = &hierComponents
-		} else {
-			// Default to FLAT when MergeStructure is empty or "FLAT"
-			flatComponents := mergeFlatComponents(componentMap)
-			mergedBOM.Components = &flatComponents
-		}
-
-		// 3.3 Dependencies: merge according to root component merge mode
-		var mergedDependencies []cdx.Dependency
-		switch MergeRootComponentMode {
-		case "PRESERVE_UNDER_NEW_ROOT":
-			mergedDependencies = mergeDependenciesPreserve(roots, allDependencies, mergedRootRef)
-		case "FLATTEN_UNDER_NEW_ROOT":
-			mergedDependencies = mergeDependenciesFlatten(roots, allDependencies, mergedRootRef)
-		default:
-			fmt.Fprintf(os.Stderr, "Error: Unknown MergeRootComponentMode: %s\n", MergeRootComponentMode)
-			os.Exit(1)
-		}
-		if len(mergedDependencies) > 0 {
-			mergedBOM.Dependencies = &mergedDependencies
+		mergedBOM, err := mergeBoms(boms, MergeStructure, MergeGroup, MergeName, MergeVersion, MergeRootComponentMode, MergePurl)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			exitCommand(1)
 		}
 
 		// 4. Output
 		buf := new(bytes.Buffer)
 		if err := cdx.NewBOMEncoder(buf, cdx.BOMFileFormatJSON).Encode(mergedBOM); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding merged BOM: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 		if MergeOutfile == "" || MergeOutfile == "-" {
 			_, err := os.Stdout.Write(buf.Bytes())
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error writing to stdout: %v\n", err)
-				os.Exit(1)
+				exitCommand(1)
 			}
 		} else {
 			if err := os.WriteFile(MergeOutfile, buf.Bytes(), 0644); err != nil {
 				fmt.Fprintf(os.Stderr, "Error writing to file %s: %v\n", MergeOutfile, err)
-				os.Exit(1)
+				exitCommand(1)
 			}
 		}
 
@@ -204,6 +96,126 @@ func init() {
 	bomUtils.AddCommand(mergeBomsCmd)
 }
 
+// mergeBoms merges the given CycloneDX BOMs into a single BOM whose root is
+// the component described by group, name, version and purl. structure is
+// FLAT or HIERARCHICAL; rootComponentMode is PRESERVE_UNDER_NEW_ROOT or
+// FLATTEN_UNDER_NEW_ROOT.
+func mergeBoms(boms []*cdx.BOM, structure, group, name, version, rootComponentMode, purl string) (*cdx.BOM, error) {
+	// 2. Extract roots, components, dependencies
+	var roots []*cdx.Component
+	var allComponents []*cdx.Component
+	var allDependencies []*cdx.Dependency
+
+	for _, bom := range boms {
+		if bom.Metadata != nil && bom.Metadata.Component != nil {
+			roots = append(roots, bom.Metadata.Component)
+		}
+		if bom.Components != nil {
+			for _, comp := range *bom.Components {
+				allComponents = append(allComponents, &comp)
+			}
+		}
+		if bom.Dependencies != nil {
+			for _, dep := range *bom.Dependencies {
+				allDependencies = append(allDependencies, &dep)
+			}
+		}
+	}
+
+	// Prepare deduplication map for FLAT mode
+	componentMap := make(map[string]*cdx.Component) // key: purl or bom-ref
+
+	// Add root components to the map first (they may be referenced in dependencies)
+	for _, root := range roots {
+		if root != nil {
+			key := root.PackageURL
+			if key == "" && root.BOMRef != "" {
+				key = root.BOMRef
+			}
+			if key != "" {
+				componentMap[key] = root
+			}
+		}
+	}
+
+	droppedCount := 0
+	for _, comp := range allComponents {
+		// Prefer PackageURL over BOMRef for consistent deduplication
+		key := comp.PackageURL
+		if key == "" && comp.BOMRef != "" {
+			key = comp.BOMRef
+		}
+		if key != "" {
+			if existing, exists := componentMap[key]; exists {
+				// Merge metadata from duplicate component
+				mergeComponentMetadata(existing, comp)
+			} else {
+				componentMap[key] = comp
+			}
+		} else {
+			// Warn about components without identifiers
+			droppedCount++
+			compName := comp.Name
+			if compName == "" {
+				compName = unnamedComponentPlaceholder
+			}
+			fmt.Fprintf(os.Stderr, "Warning: Dropping component '%s' (type: %s) - missing both PackageURL and BOMRef\n",
+				compName, comp.Type)
+		}
+	}
+	if droppedCount > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: Dropped %d component(s) without identifiers\n", droppedCount)
+	}
+
+	// 3. Merge logic
+	// 3.1 Metadata: create new BOM object with the given root
+	mergedBOM := &cdx.BOM{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  cdx.SpecVersion1_6,
+		SerialNumber: generateSerialNumber(),
+		Version:      1,
+		Metadata: &cdx.Metadata{
+			Component: &cdx.Component{
+				Type:    cdx.ComponentTypeApplication,
+				Name:    name,
+				Group:   group,
+				Version: version,
+			},
+		},
+	}
+
+	// Set merged root component BOMRef and PackageURL
+	mergedRootRef := setMergedRootComponent(
+		mergedBOM.Metadata.Component,
+		group, name, version, purl,
+	)
+
+	// 3.2 Components: FLAT or HIERARCHICAL
+	if structure == "HIERARCHICAL" {
+		hierComponents := mergeHierarchicalComponents(roots, boms)
+		mergedBOM.Components = &hierComponents
+	} else {
+		// Default to FLAT when structure is empty or "FLAT"
+		flatComponents := mergeFlatComponents(componentMap)
+		mergedBOM.Components = &flatComponents
+	}
+
+	// 3.3 Dependencies: merge according to root component merge mode
+	var mergedDependencies []cdx.Dependency
+	switch rootComponentMode {
+	case "PRESERVE_UNDER_NEW_ROOT":
+		mergedDependencies = mergeDependenciesPreserve(roots, allDependencies, mergedRootRef)
+	case "FLATTEN_UNDER_NEW_ROOT":
+		mergedDependencies = mergeDependenciesFlatten(roots, allDependencies, mergedRootRef)
+	default:
+		return nil, fmt.Errorf("unknown root component merge mode: %s", rootComponentMode)
+	}
+	if len(mergedDependencies) > 0 {
+		mergedBOM.Dependencies = &mergedDependencies
+	}
+	return mergedBOM, nil
+}
+
 // generateSerialNumber creates a UUID-style serial number for the merged BOM
 func generateSerialNumber() string {
 	b := make([]byte, 16)
@@ -0,0 +1,271 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	monorepoBase      string
+	monorepoHead      string
+	monorepoDir       string
+	monorepoPaths     []string
+	monorepoLifecycle string
+	monorepoOutfile   string
+	monorepoVcsType   string
+)
+
+var monorepoCmd = &cobra.Command{
+	Use:   "monorepo",
+	Short: "Helpers for repositories that hold several components",
+}
+
+var monorepoPlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Plan releases for monorepo components changed since a base ref",
+	Long: `Compares two git refs of the local checkout and, for every --repo-path that has
+changes between them, obtains the next version from ReARM via getNewVersionProgrammatic.
+Components are identified the same way as in getversion and addrelease: by --vcsuri plus
+the repository path.
+
+The result is a JSON array ready to be passed to 'addreleases --infile'. Add outbound
+deliverables and artifacts to the entries as your build produces them before submitting.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Fprintln(os.Stderr, "Using ReARM at", rearmUri)
+		}
+
+		changedFiles, err := runGit(monorepoDir, "diff", "--name-only", monorepoBase, monorepoHead)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error computing changed files:", err)
+			exitCommand(1)
+		}
+		headCommit, err := gitCommitDetails(monorepoDir, monorepoHead)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error reading head commit:", err)
+			exitCommand(1)
+		}
+
+		plan := []map[string]interface{}{}
+		for _, repoPathEntry := range monorepoPaths {
+			rp := normalizeRepoPath(repoPathEntry)
+			label := rp
+			if label == "" {
+				label = "."
+			}
+			if !pathChanged(rp, changedFiles) {
+				if debug == "true" {
+					fmt.Fprintln(os.Stderr, "No changes in", label)
+				}
+				continue
+			}
+			commitsOfPath, err := gitCommitsForPath(monorepoDir, monorepoBase, monorepoHead, rp)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error reading commits for", label+":", err)
+				exitCommand(1)
+			}
+			sce := map[string]interface{}{"uri": vcsUri, "type": monorepoVcsType}
+			for k, v := range headCommit {
+				sce[k] = v
+			}
+
+			versionInput := map[string]interface{}{
+				"branch":          branch,
+				"vcsUri":          vcsUri,
+				"sourceCodeEntry": sce,
+				"commits":         commitsOfPath,
+				"onlyVersion":     onlyVersion,
+			}
+			if len(rp) > 0 {
+				versionInput["repoPath"] = rp
+			}
+			if len(perspective) > 0 {
+				versionInput["perspective"] = perspective
+			}
+			query := `
+				mutation getNewVersionProgrammatic ($GetNewVersionInput: GetNewVersionInput!) {
+					getNewVersionProgrammatic(newVersionInput:$GetNewVersionInput) {
+						version
+					}
+				}
+			`
+			data, err := sendGraphQLRequest(query, map[string]interface{}{"GetNewVersionInput": versionInput}, rearmUri+"/graphql")
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error obtaining version for", label)
+				printGqlError(err)
+				exitCommand(1)
+			}
+			var resp struct {
+				Version string `json:"version"`
+			}
+			if err := decodeInto(data["getNewVersionProgrammatic"], &resp); err != nil || resp.Version == "" {
+				fmt.Fprintln(os.Stderr, "Error: no version returned for", label)
+				exitCommand(1)
+			}
+			fmt.Fprintf(os.Stderr, "%s -> %s\n", label, resp.Version)
+
+			entry := map[string]interface{}{
+				"branch":          branch,
+				"version":         resp.Version,
+				"vcsUri":          vcsUri,
+				"sourceCodeEntry": sce,
+			}
+			if len(rp) > 0 {
+				entry["repoPath"] = rp
+			}
+			if len(commitsOfPath) > 0 {
+				entry["commits"] = commitsOfPath
+			}
+			if len(monorepoLifecycle) > 0 {
+				entry["lifecycle"] = strings.ToUpper(monorepoLifecycle)
+			}
+			plan = append(plan, entry)
+		}
+
+		if len(plan) == 0 {
+			fmt.Fprintln(os.Stderr, "No component paths changed between", monorepoBase, "and", monorepoHead)
+		}
+		out, _ := json.MarshalIndent(plan, "", "  ")
+		if monorepoOutfile == "" || monorepoOutfile == "-" {
+			fmt.Println(string(out))
+			return
+		}
+		if err := os.WriteFile(monorepoOutfile, append(out, '\n'), 0644); err != nil {
+			fmt.Fprintln(os.Stderr, "Error writing plan:", err)
+			exitCommand(1)
+		}
+	},
+}
+
+func runGit(dir string, args ...string) ([]string, error) {
+	c := exec.Command("git", args...)
+	c.Dir = dir
+	out, err := c.Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("git %s: %s", strings.Join(args, " "), strings.TrimSpace(string(ee.Stderr)))
+		}
+		return nil, err
+	}
+	var lines []string
+	for _, l := range strings.Split(string(out), "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines, nil
+}
+
+// gitCommitDetails returns the commit hash, date and subject of ref in the
+// shape expected by sourceCodeEntry.
+func gitCommitDetails(dir string, ref string) (map[string]interface{}, error) {
+	lines, err := runGit(dir, "log", "-1", "--date=iso-strict", "--pretty=%H|||%ad|||%s", ref)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("no commit found for %s", ref)
+	}
+	parts := strings.SplitN(lines[0], "|||", 3)
+	if len(parts) < 3 {
+		return nil, fmt.Errorf("unexpected git log output: %s", lines[0])
+	}
+	return map[string]interface{}{"commit": parts[0], "dateActual": parts[1], "commitMessage": parts[2]}, nil
+}
+
+// gitCommitsForPath lists commits between base and head that touch repoPath,
+// using the same field layout as the --commits flag of getversion.
+func gitCommitsForPath(dir, base, head, repoPath string) ([]map[string]interface{}, error) {
+	pathspec := repoPath
+	if pathspec == "" {
+		// whole repository, regardless of where in the checkout dir is
+		pathspec = ":/"
+	}
+	lines, err := runGit(dir, "log", "--date=iso-strict", "--pretty=%H|||%ad|||%s|||%an|||%ae", base+".."+head, "--", pathspec)
+	if err != nil {
+		return nil, err
+	}
+	commitList := []map[string]interface{}{}
+	for _, l := range lines {
+		parts := strings.SplitN(l, "|||", 5)
+		if len(parts) < 3 {
+			continue
+		}
+		c := map[string]interface{}{"commit": parts[0], "dateActual": parts[1], "commitMessage": parts[2]}
+		if len(parts) == 5 {
+			c["commitAuthor"] = parts[3]
+			c["commitEmail"] = parts[4]
+		}
+		commitList = append(commitList, c)
+	}
+	return commitList, nil
+}
+
+// normalizeRepoPath cleans a repository path; the repository root, given as
+// ".", "./" or "/", becomes the empty path.
+func normalizeRepoPath(p string) string {
+	p = path.Clean(strings.TrimPrefix(strings.TrimSpace(p), "./"))
+	p = strings.TrimSuffix(p, "/")
+	if p == "." || p == "/" || p == "" {
+		return ""
+	}
+	return p
+}
+
+// pathChanged reports whether any changed file lies below repoPath. The empty
+// path is the repository root, which every change touches.
+func pathChanged(repoPath string, changedFiles []string) bool {
+	if repoPath == "" {
+		return len(changedFiles) > 0
+	}
+	for _, f := range changedFiles {
+		if f == repoPath || strings.HasPrefix(f, repoPath+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	monorepoPlanCmd.PersistentFlags().StringVar(&monorepoBase, "base", "", "Git ref to compare against, e.g. the commit of the previous release")
+	monorepoPlanCmd.PersistentFlags().StringVar(&monorepoHead, "head", "HEAD", "Git ref being released")
+	monorepoPlanCmd.PersistentFlags().StringVar(&monorepoDir, "repo-dir", ".", "Path to the local git checkout")
+	monorepoPlanCmd.PersistentFlags().StringArrayVar(&monorepoPaths, "repo-path", []string{}, "Repository path of a component, \".\" for the repository root (multiple allowed)")
+	monorepoPlanCmd.PersistentFlags().StringVar(&vcsUri, "vcsuri", "", "URI of the monorepo as registered in ReARM")
+	monorepoPlanCmd.PersistentFlags().StringVar(&monorepoVcsType, "vcstype", "git", "Type of VCS repository: git, svn, mercurial")
+	monorepoPlanCmd.PersistentFlags().StringVarP(&branch, "branch", "b", "", "Name of VCS Branch used")
+	monorepoPlanCmd.PersistentFlags().StringVar(&monorepoLifecycle, "lifecycle", "", "(Optional) Lifecycle to set on every planned release")
+	monorepoPlanCmd.PersistentFlags().BoolVar(&onlyVersion, "onlyversion", false, "(Optional) Only obtain versions without creating pending releases")
+	monorepoPlanCmd.PersistentFlags().StringVar(&perspective, "perspective", "", "(Optional) Perspective UUID, passed to getNewVersionProgrammatic for FREEFORM keys scoped to a perspective")
+	monorepoPlanCmd.PersistentFlags().StringVar(&monorepoOutfile, "outfile", "", "Output file for the batch JSON (default: stdout)")
+	monorepoPlanCmd.MarkPersistentFlagRequired("base")
+	monorepoPlanCmd.MarkPersistentFlagRequired("repo-path")
+	monorepoPlanCmd.MarkPersistentFlagRequired("vcsuri")
+	monorepoPlanCmd.MarkPersistentFlagRequired("branch")
+
+	monorepoCmd.AddCommand(monorepoPlanCmd)
+	rootCmd.AddCommand(monorepoCmd)
+}
@@ -205,7 +205,7 @@ The product directory name will be the lowercase snake_case version of the produ
 	Run: func(cmd *cobra.Command, args []string) {
 		if productName == "" {
 			fmt.Fprintf(os.Stderr, "Error: product name is required\n")
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Generate UUID if not provided
@@ -225,12 +225,12 @@ The product directory name will be the lowercase snake_case version of the produ
 		// Check if directory already exists
 		if _, err := os.Stat(productDir); err == nil {
 			fmt.Fprintf(os.Stderr, "Error: product with name '%s' already exists at %s\n", productName, productDir)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		if err := os.MkdirAll(productDir, 0755); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to create directory %s: %v\n", productDir, err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Create product structure
@@ -244,7 +244,7 @@ The product directory name will be the lowercase snake_case version of the produ
 		yamlPath := filepath.Join(productDir, "product.yaml")
 		if err := writeYAML(yamlPath, product); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to write product.yaml: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		fmt.Printf("Successfully created/updated product: %s\n", productName)
@@ -262,7 +262,7 @@ The component directory name will be the lowercase snake_case version of the com
 	Run: func(cmd *cobra.Command, args []string) {
 		if componentNameFlag == "" {
 			fmt.Fprintf(os.Stderr, "Error: component name is required\n")
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Generate UUID if not provided
@@ -282,12 +282,12 @@ The component directory name will be the lowercase snake_case version of the com
 		// Check if directory already exists
 		if _, err := os.Stat(componentDir); err == nil {
 			fmt.Fprintf(os.Stderr, "Error: component with name '%s' already exists at %s\n", componentNameFlag, componentDir)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		if err := os.MkdirAll(componentDir, 0755); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to create directory %s: %v\n", componentDir, err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Create component structure
@@ -301,7 +301,7 @@ The component directory name will be the lowercase snake_case version of the com
 		yamlPath := filepath.Join(componentDir, "component.yaml")
 		if err := writeYAML(yamlPath, component); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to write component.yaml: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		fmt.Printf("Successfully created/updated component: %s\n", componentNameFlag)
@@ -319,25 +319,25 @@ The component can be specified by name or UUID.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if releaseComponent == "" {
 			fmt.Fprintf(os.Stderr, "Error: component is required\n")
-			os.Exit(1)
+			exitCommand(1)
 		}
 		if componentReleaseVersion == "" {
 			fmt.Fprintf(os.Stderr, "Error: version is required\n")
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Find component by name or UUID
 		componentDir, componentData, err := findComponent(contentDir, releaseComponent)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Validate artifacts exist if provided (BEFORE creating any directories)
 		if len(componentReleaseArtifacts) > 0 {
 			if err := validateArtifactsExist(contentDir, componentReleaseArtifacts); err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				exitCommand(1)
 			}
 		}
 
@@ -347,12 +347,12 @@ The component can be specified by name or UUID.`,
 		// Check if release already exists
 		if _, err := os.Stat(releaseDir); err == nil {
 			fmt.Fprintf(os.Stderr, "Error: release version '%s' already exists for component '%s'\n", componentReleaseVersion, componentData.Name)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		if err := os.MkdirAll(releaseDir, 0755); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to create release directory %s: %v\n", releaseDir, err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Generate UUID if not provided
@@ -381,14 +381,14 @@ The component can be specified by name or UUID.`,
 		releaseYamlPath := filepath.Join(releaseDir, "release.yaml")
 		if err := writeYAML(releaseYamlPath, release); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to write release.yaml: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Create collections directory
 		collectionsDir := filepath.Join(releaseDir, "collections")
 		if err := os.MkdirAll(collectionsDir, 0755); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to create collections directory: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Create initial collection
@@ -405,7 +405,7 @@ The component can be specified by name or UUID.`,
 		collectionYamlPath := filepath.Join(collectionsDir, "1.yaml")
 		if err := writeYAML(collectionYamlPath, collection); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to write collection: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		fmt.Printf("Successfully created component release: %s\n", componentReleaseVersion)
@@ -428,19 +428,19 @@ The artifact file is named with its UUID.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if artifactName == "" {
 			fmt.Fprintf(os.Stderr, "Error: artifact name is required\n")
-			os.Exit(1)
+			exitCommand(1)
 		}
 		if artifactType == "" {
 			fmt.Fprintf(os.Stderr, "Error: artifact type is required\n")
-			os.Exit(1)
+			exitCommand(1)
 		}
 		if artifactMediaType == "" {
 			fmt.Fprintf(os.Stderr, "Error: media type is required\n")
-			os.Exit(1)
+			exitCommand(1)
 		}
 		if artifactUrl == "" {
 			fmt.Fprintf(os.Stderr, "Error: url is required\n")
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Validate artifact type
@@ -459,7 +459,7 @@ The artifact file is named with its UUID.`,
 		}
 		if !validTypes[artifactType] {
 			fmt.Fprintf(os.Stderr, "Error: invalid artifact type '%s'. Must be one of: ATTESTATION, BOM, BUILD_META, CERTIFICATION, FORMULATION, LICENSE, RELEASE_NOTES, SECURITY_TXT, THREAT_MODEL, VULNERABILITIES, OTHER\n", artifactType)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Generate UUID if not provided
@@ -469,14 +469,14 @@ The artifact file is named with its UUID.`,
 		artifactsDir := filepath.Join(contentDir, "artifacts")
 		if err := os.MkdirAll(artifactsDir, 0755); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to create artifacts directory: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Check if artifact file already exists
 		artifactPath := filepath.Join(artifactsDir, artUuid+".yaml")
 		if _, err := os.Stat(artifactPath); err == nil {
 			fmt.Fprintf(os.Stderr, "Error: artifact with UUID '%s' already exists at %s\n", artUuid, artifactPath)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Parse hashes
@@ -485,13 +485,13 @@ The artifact file is named with its UUID.`,
 			parts := strings.SplitN(hash, "=", 2)
 			if len(parts) != 2 {
 				fmt.Fprintf(os.Stderr, "Error: invalid hash format '%s'. Expected format: algorithm=value (e.g., sha256=abcd)\n", hash)
-				os.Exit(1)
+				exitCommand(1)
 			}
 			// Normalize algorithm name
 			algType, err := normalizeHashAlgorithm(parts[0])
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				exitCommand(1)
 			}
 			checksums = append(checksums, Checksum{
 				AlgType:  algType,
@@ -520,7 +520,7 @@ The artifact file is named with its UUID.`,
 		// Write YAML file
 		if err := writeYAML(artifactPath, artifact); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to write artifact.yaml: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		fmt.Printf("Successfully created artifact: %s\n", artifactName)
@@ -533,7 +533,7 @@ The artifact file is named with its UUID.`,
 			fmt.Println("\nLinking artifact to releases...")
 			if err := addArtifactToReleases(contentDir, artUuid, artifactComponents, artifactComponentReleases, artifactProducts, artifactProductReleases); err != nil {
 				fmt.Fprintf(os.Stderr, "Error linking artifact to releases: %v\n", err)
-				os.Exit(1)
+				exitCommand(1)
 			}
 		}
 	},
@@ -549,31 +549,31 @@ Optionally, components can be linked to the release by providing paired componen
 	Run: func(cmd *cobra.Command, args []string) {
 		if productReleaseProduct == "" {
 			fmt.Fprintf(os.Stderr, "Error: product is required\n")
-			os.Exit(1)
+			exitCommand(1)
 		}
 		if productReleaseVersion == "" {
 			fmt.Fprintf(os.Stderr, "Error: version is required\n")
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Validate component and component_release flags match
 		if len(productReleaseComponents) != len(productReleaseComponentReleases) {
 			fmt.Fprintf(os.Stderr, "Error: number of --component flags (%d) must match number of --component_release flags (%d)\n", len(productReleaseComponents), len(productReleaseComponentReleases))
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Find product by name or UUID
 		productDir, productData, err := findProduct(contentDir, productReleaseProduct)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Validate artifacts exist if provided (BEFORE creating any directories)
 		if len(productReleaseArtifacts) > 0 {
 			if err := validateArtifactsExist(contentDir, productReleaseArtifacts); err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				exitCommand(1)
 			}
 		}
 
@@ -583,12 +583,12 @@ Optionally, components can be linked to the release by providing paired componen
 		// Check if release already exists
 		if _, err := os.Stat(releaseDir); err == nil {
 			fmt.Fprintf(os.Stderr, "Error: release version '%s' already exists for product '%s'\n", productReleaseVersion, productData.Name)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		if err := os.MkdirAll(releaseDir, 0755); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to create release directory %s: %v\n", releaseDir, err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Generate UUID if not provided
@@ -612,14 +612,14 @@ Optionally, components can be linked to the release by providing paired componen
 			componentDir, componentData, err := findComponent(contentDir, componentIdentifier)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error finding component '%s': %v\n", componentIdentifier, err)
-				os.Exit(1)
+				exitCommand(1)
 			}
 
 			// Find component release
 			componentReleaseUuid, err := findComponentRelease(componentDir, componentReleaseIdentifier)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error finding component release '%s' for component '%s': %v\n", componentReleaseIdentifier, componentData.Name, err)
-				os.Exit(1)
+				exitCommand(1)
 			}
 
 			components = append(components, ProductReleaseComponent{
@@ -643,14 +643,14 @@ Optionally, components can be linked to the release by providing paired componen
 		releaseYamlPath := filepath.Join(releaseDir, "release.yaml")
 		if err := writeYAML(releaseYamlPath, release); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to write release.yaml: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Create collections directory
 		collectionsDir := filepath.Join(releaseDir, "collections")
 		if err := os.MkdirAll(collectionsDir, 0755); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to create collections directory: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Create initial collection
@@ -667,7 +667,7 @@ Optionally, components can be linked to the release by providing paired componen
 		collectionYamlPath := filepath.Join(collectionsDir, "1.yaml")
 		if err := writeYAML(collectionYamlPath, collection); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to write collection: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		fmt.Printf("Successfully created product release: %s\n", productReleaseVersion)
@@ -694,13 +694,13 @@ If the artifact is already in the latest collection, no changes are made.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if addArtifactToReleasesArtifactUuid == "" {
 			fmt.Fprintf(os.Stderr, "Error: artifactuuid is required\n")
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Use the helper function to add artifact to releases
 		if err := addArtifactToReleases(contentDir, addArtifactToReleasesArtifactUuid, addArtifactToReleasesComponents, addArtifactToReleasesComponentReleases, addArtifactToReleasesProducts, addArtifactToReleasesProductReleases); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 	},
 }
@@ -714,29 +714,29 @@ var add_distribution_to_component_releaseCmd = &cobra.Command{
 		// Validate required flags
 		if distComponent == "" {
 			fmt.Fprintf(os.Stderr, "Error: --component is required\n")
-			os.Exit(1)
+			exitCommand(1)
 		}
 		if distComponentRelease == "" {
 			fmt.Fprintf(os.Stderr, "Error: --component_release is required\n")
-			os.Exit(1)
+			exitCommand(1)
 		}
 		if distUrl == "" {
 			fmt.Fprintf(os.Stderr, "Error: --url is required\n")
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Find component
 		componentDir, componentData, err := findComponent(contentDir, distComponent)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Find component release
 		releaseDir, _, _, err := findComponentReleaseDir(componentDir, distComponentRelease)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Read existing release.yaml
@@ -744,13 +744,13 @@ var add_distribution_to_component_releaseCmd = &cobra.Command{
 		data, err := os.ReadFile(releaseYamlPath)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to read release.yaml: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		var release ComponentRelease
 		if err := yaml.Unmarshal(data, &release); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to parse release.yaml: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		// Parse hashes
@@ -759,14 +759,14 @@ var add_distribution_to_component_releaseCmd = &cobra.Command{
 			parts := strings.SplitN(hash, "=", 2)
 			if len(parts) != 2 {
 				fmt.Fprintf(os.Stderr, "Error: invalid hash format '%s'. Expected format: algorithm=value\n", hash)
-				os.Exit(1)
+				exitCommand(1)
 			}
 
 			algorithm, value := parts[0], parts[1]
 			normalizedAlg, err := normalizeHashAlgorithm(algorithm)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				exitCommand(1)
 			}
 
 			checksums = append(checksums, Checksum{
@@ -794,7 +794,7 @@ var add_distribution_to_component_releaseCmd = &cobra.Command{
 		// Write updated release.yaml
 		if err := writeYAML(releaseYamlPath, release); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to write release.yaml: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		fmt.Printf("Successfully added distribution to component release\n")
@@ -0,0 +1,366 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+var (
+	uploadConcurrency int
+	uploadRetries     int
+)
+
+// uploadRetryDelay is the wait before the first retry of a failed upload; it
+// doubles with every further attempt.
+var uploadRetryDelay = time.Second
+
+const (
+	uploadStatusUploaded = "UPLOADED"
+	uploadStatusFailed   = "FAILED"
+)
+
+// deferredUpload is one artifact of an addrelease call that is attached with
+// addArtifactProgrammatic after the release has been created. Files are read
+// and digested up front so a missing file fails the command before anything
+// is created.
+type deferredUpload struct {
+	belongsTo   string
+	deliverable string
+	filePath    string
+	artifacts   []Artifact
+	locationMap map[string][]string
+	filesMap    map[string]interface{}
+}
+
+// ArtifactUploadResult reports the outcome of one deferred upload.
+type ArtifactUploadResult struct {
+	DisplayIdentifier string `json:"displayIdentifier"`
+	FilePath          string `json:"filePath"`
+	BelongsTo         string `json:"belongsTo"`
+	Deliverable       string `json:"deliverable,omitempty"`
+	Status            string `json:"status"`
+	Attempts          int    `json:"attempts"`
+	Error             string `json:"error,omitempty"`
+}
+
+// ParallelAddReleaseResult is printed by addrelease when --upload-concurrency
+// is set.
+type ParallelAddReleaseResult struct {
+	Release map[string]interface{} `json:"release"`
+	Uploads []ArtifactUploadResult `json:"uploads"`
+	Failed  int                    `json:"failed"`
+}
+
+// prepareDeferredUploads takes the release, deliverable and source code entry
+// artifacts out of the addrelease flags and turns each into its own upload.
+// The flags are cleared so the release itself is created without files.
+func prepareDeferredUploads() []*deferredUpload {
+	var uploads []*deferredUpload
+	add := func(belongsTo, deliverable, prefix string, arts []Artifact) {
+		for _, a := range arts {
+			u := &deferredUpload{
+				belongsTo:   belongsTo,
+				deliverable: deliverable,
+				filePath:    a.FilePath,
+				locationMap: make(map[string][]string),
+				filesMap:    make(map[string]interface{}),
+			}
+			filesCounter := 0
+			u.artifacts = *processArtifactsInput(&[]Artifact{a}, prefix, &filesCounter, &u.locationMap, &u.filesMap)
+			uploads = append(uploads, u)
+		}
+	}
+
+	if releaseArts != "" {
+		var arts []Artifact
+		if err := json.Unmarshal([]byte(releaseArts), &arts); err != nil {
+			fmt.Println("Error parsing Release Artifact Input: ", err)
+			exitCommand(1)
+		}
+		add(artifactOwnerRelease, "", "variables.artifactInput.releaseArtifacts.", skipUploadedArtifacts(arts, uploadTargetRelease))
+		releaseArts = ""
+	}
+	// scearts are only sent along with --commit, same as in the single request
+	if sceArts != "" && commit != "" {
+		var arts []Artifact
+		if err := json.Unmarshal([]byte(sceArts), &arts); err != nil {
+			fmt.Fprintln(os.Stderr, "Error parsing Artifact Input: ", err)
+			exitCommand(1)
+		}
+		add(artifactOwnerSce, "", "variables.artifactInput.sceArtifacts.0.artifacts.", skipUploadedArtifacts(arts, uploadTargetSce))
+		sceArts = ""
+	}
+	if len(odelArtsJson) > 0 {
+		if len(odelArtsJson) != len(odelId) {
+			fmt.Println("number of --odelartsjson flags must be either zero or match number of --odelid flags")
+			exitCommand(2)
+		}
+		for i, artifactsInputString := range odelArtsJson {
+			var arts []Artifact
+			if err := json.Unmarshal([]byte(artifactsInputString), &arts); err != nil {
+				fmt.Fprintln(os.Stderr, "Error parsing Artifact Input: ", err)
+				exitCommand(1)
+			}
+			add(artifactOwnerDeliverable, odelId[i], "variables.artifactInput.deliverableArtifacts.0.artifacts.", skipUploadedArtifacts(arts, uploadTargetDeliverable+odelId[i]))
+		}
+		odelArtsJson = nil
+	}
+	return uploads
+}
+
+// deferReleaseLifecycle lowers a lifecycle past DRAFT in body to DRAFT and
+// returns it, or returns an empty string when there is nothing to defer. The
+// release then only reaches it once every deferred upload is attached, so
+// nothing acting on an ASSEMBLED release, like product auto-integration,
+// sees it without its artifacts.
+func deferReleaseLifecycle(body map[string]interface{}) string {
+	lc, _ := body["lifecycle"].(string)
+	switch lc {
+	case "", "PENDING", "DRAFT":
+		return ""
+	}
+	body["lifecycle"] = "DRAFT"
+	return lc
+}
+
+// finishDeferredUploads reads the release created by addReleaseProgrammatic
+// and attaches the deferred uploads to it. A failed upload does not stop the
+// others; the command exits with status 1 after reporting every result. When
+// finalLifecycle is set the release is moved to it once all uploads
+// succeeded, otherwise it stays in DRAFT.
+func finishDeferredUploads(resp *resty.Response, err error, uploads []*deferredUpload, finalLifecycle string) {
+	if err != nil || resp.StatusCode() != 200 {
+		handleResponse(err, resp)
+		exitCommand(1)
+	}
+	data, err := graphQLResponseData(resp, err)
+	if err != nil {
+		fmt.Println(resp)
+		fmt.Fprintln(os.Stderr, "Error: release was not created, no artifacts were uploaded:", err)
+		exitCommand(1)
+	}
+	release, _ := data["addReleaseProgrammatic"].(map[string]interface{})
+	releaseUuid, _ := release["uuid"].(string)
+	if releaseUuid == "" {
+		fmt.Println(resp)
+		fmt.Fprintln(os.Stderr, "Error: release was not created, no artifacts were uploaded")
+		exitCommand(1)
+	}
+
+	results := uploadDeferredArtifacts(releaseUuid, uploads)
+	out := ParallelAddReleaseResult{Release: release, Uploads: results}
+	for _, r := range results {
+		if r.Status == uploadStatusFailed {
+			out.Failed++
+		}
+	}
+	if out.Failed == 0 && finalLifecycle != "" {
+		lc, err := updateReleaseLifecycle(releaseUuid, finalLifecycle)
+		if err != nil {
+			emitJson(out)
+			fmt.Fprintf(os.Stderr, "Error: all artifacts were uploaded but release %s could not be moved from DRAFT to %s: %v\n", releaseUuid, finalLifecycle, err)
+			exitCommand(1)
+		}
+		release["lifecycle"] = lc
+	}
+	emitJson(out)
+	if out.Failed > 0 {
+		fmt.Fprintf(os.Stderr, "Error: %d of %d artifact upload(s) failed for release %s\n", out.Failed, len(results), releaseUuid)
+		if finalLifecycle != "" {
+			fmt.Fprintf(os.Stderr, "Release %s was left in DRAFT instead of %s\n", releaseUuid, finalLifecycle)
+		}
+		exitCommand(1)
+	}
+}
+
+// updateReleaseLifecycle moves a release to lifecycle and returns the
+// lifecycle ReARM reports back.
+func updateReleaseLifecycle(releaseUuid, lifecycle string) (string, error) {
+	query := `
+		mutation updateReleaseLifecycle($release: ID!, $newLifecycle: ReleaseLifecycleEnum!) {
+			updateReleaseLifecycle(release: $release, newLifecycle: $newLifecycle) {
+				uuid
+				lifecycle
+			}
+		}
+	`
+	data, err := sendGraphQLRequest(query, map[string]interface{}{"release": releaseUuid, "newLifecycle": lifecycle}, rearmUri+"/graphql")
+	if err != nil {
+		return "", err
+	}
+	updated, _ := data["updateReleaseLifecycle"].(map[string]interface{})
+	lc, _ := updated["lifecycle"].(string)
+	if lc == "" {
+		lc = lifecycle
+	}
+	return lc, nil
+}
+
+// uploadDeferredArtifacts attaches uploads to the release with at most
+// --upload-concurrency requests in flight.
+func uploadDeferredArtifacts(releaseUuid string, uploads []*deferredUpload) []ArtifactUploadResult {
+	results := make([]ArtifactUploadResult, len(uploads))
+	if len(uploads) == 0 {
+		return results
+	}
+	// deliverables and the source code entry are created with the release,
+	// so their UUIDs are only known once it exists
+	needsTargets := false
+	for _, u := range uploads {
+		if u.belongsTo != artifactOwnerRelease {
+			needsTargets = true
+		}
+	}
+	var sceUuid string
+	deliverableUuids := make(map[string]string)
+	if needsTargets {
+		var rc releaseContent
+		if err := fetchReleaseContent(releaseUuid, "", &rc); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not read release %s to resolve upload targets: %v\n", releaseUuid, err)
+		}
+		if rc.SourceCodeEntryDetails != nil {
+			sceUuid = rc.SourceCodeEntryDetails.Uuid
+		}
+		for _, d := range rc.deliverables() {
+			deliverableUuids[d.DisplayIdentifier] = d.Uuid
+		}
+	}
+
+	sem := make(chan struct{}, uploadConcurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for i, u := range uploads {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, u *deferredUpload) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = uploadDeferredArtifact(releaseUuid, sceUuid, deliverableUuids, u)
+			mu.Lock()
+			defer mu.Unlock()
+			r := results[i]
+			if r.Error != "" {
+				fmt.Fprintf(os.Stderr, "  %s %s after %d attempt(s): %s\n", r.Status, r.FilePath, r.Attempts, r.Error)
+			} else {
+				fmt.Fprintf(os.Stderr, "  %s %s\n", r.Status, r.FilePath)
+			}
+		}(i, u)
+	}
+	wg.Wait()
+	return results
+}
+
+// retryableUpload tells transport errors and 5xx responses, which may pass on
+// a later attempt, from rejections by ReARM such as 4xx responses or GraphQL
+// errors, which would fail the same way again.
+func retryableUpload(resp *resty.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode() >= 500
+}
+
+// uploadDeferredArtifact sends one upload, retrying transport errors and 5xx
+// responses up to --upload-retries times with exponential backoff. Before a
+// retry the release is read again, and when the file is already attached the
+// upload counts as done.
+func uploadDeferredArtifact(releaseUuid, sceUuid string, deliverableUuids map[string]string, u *deferredUpload) ArtifactUploadResult {
+	result := ArtifactUploadResult{
+		DisplayIdentifier: u.artifacts[0].DisplayIdentifier,
+		FilePath:          u.filePath,
+		BelongsTo:         u.belongsTo,
+		Deliverable:       u.deliverable,
+		Status:            uploadStatusFailed,
+	}
+	artifactInput := map[string]interface{}{"release": releaseUuid}
+	target := uploadTargetRelease
+	switch u.belongsTo {
+	case artifactOwnerSce:
+		if sceUuid == "" {
+			result.Error = "release has no source code entry"
+			return result
+		}
+		artifactInput["sceArtifacts"] = []SceArtifactGroup{{Sce: sceUuid, Artifacts: u.artifacts}}
+		target = uploadTargetSce
+	case artifactOwnerDeliverable:
+		delUuid, ok := deliverableUuids[u.deliverable]
+		if !ok {
+			result.Error = "deliverable " + u.deliverable + " not found on release"
+			return result
+		}
+		artifactInput["deliverableArtifacts"] = []DeliverableArtifactGroup{{Deliverable: delUuid, Artifacts: u.artifacts}}
+		target = uploadTargetDeliverable + delUuid
+	default:
+		artifactInput["releaseArtifacts"] = u.artifacts
+	}
+	variables := map[string]interface{}{"artifactInput": artifactInput}
+
+	delay := uploadRetryDelay
+	for {
+		result.Attempts++
+		resp, err := postAddArtifact(variables, u.locationMap, u.filesMap)
+		_, dataErr := graphQLResponseData(resp, err)
+		if dataErr == nil {
+			result.Status = uploadStatusUploaded
+			result.Error = ""
+			return result
+		}
+		result.Error = dataErr.Error()
+		if result.Attempts > uploadRetries || !retryableUpload(resp, err) {
+			return result
+		}
+		time.Sleep(delay)
+		delay *= 2
+		// the failed attempt may have been applied before the response was
+		// lost, retrying it would attach the artifact twice
+		applied, checkErr := deferredUploadApplied(releaseUuid, target, u)
+		if checkErr != nil {
+			result.Error += "; not retried, could not check whether the failed attempt was applied: " + checkErr.Error()
+			return result
+		}
+		if applied {
+			result.Status = uploadStatusUploaded
+			result.Error = ""
+			return result
+		}
+	}
+}
+
+// deferredUploadApplied reports whether the file of u is already attached to
+// target on the release.
+func deferredUploadApplied(releaseUuid, target string, u *deferredUpload) (bool, error) {
+	if u.filePath == "" {
+		return false, fmt.Errorf("artifact has no file to compare")
+	}
+	digest, err := fileSha256(u.filePath)
+	if err != nil {
+		return false, err
+	}
+	var rc releaseContent
+	if err := fetchReleaseContent(releaseUuid, "", &rc); err != nil {
+		return false, err
+	}
+	return uploadedArtifactIndex(&rc)[target][digest] != "", nil
+}
@@ -0,0 +1,109 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// errPollTimeout is returned by pollUntil when the deadline passes before
+// the check function reports completion.
+var errPollTimeout = errors.New("timed out while polling")
+
+// progressSpinner renders a single-line spinner with a message that can be
+// swapped while it runs. Used by the long-running polling commands
+// (probesbom, release wait) so the user can see that the CLI is still alive.
+type progressSpinner struct {
+	out  io.Writer
+	done chan struct{}
+	once sync.Once
+	mu   sync.Mutex
+	msg  string
+}
+
+func startProgressSpinner(out io.Writer, msg string) *progressSpinner {
+	s := &progressSpinner{out: out, done: make(chan struct{}), msg: msg}
+	go func() {
+		symbols := []string{"|", "/", "-", "\\"}
+		i := 0
+		for {
+			select {
+			case <-s.done:
+				fmt.Fprint(s.out, "\r                                                    \r")
+				return
+			case <-time.After(1 * time.Second):
+				s.mu.Lock()
+				m := s.msg
+				s.mu.Unlock()
+				fmt.Fprintf(s.out, "\r%-50s %s", m, symbols[i%4])
+				i++
+			}
+		}
+	}()
+	return s
+}
+
+func (s *progressSpinner) SetMessage(msg string) {
+	s.mu.Lock()
+	s.msg = msg
+	s.mu.Unlock()
+}
+
+// Stop clears the spinner line. Safe to call more than once.
+func (s *progressSpinner) Stop() {
+	s.once.Do(func() {
+		close(s.done)
+		time.Sleep(200 * time.Millisecond) // let goroutine clear the line
+	})
+}
+
+// pollUntil sleeps for interval and then calls check, repeating until check
+// reports done or returns an error. Returns errPollTimeout once timeout has
+// elapsed without completion.
+func pollUntil(interval time.Duration, timeout time.Duration, check func() (bool, error)) error {
+	deadline := time.After(timeout)
+	for {
+		// Check deadline before sleeping
+		select {
+		case <-deadline:
+			return errPollTimeout
+		default:
+		}
+
+		time.Sleep(interval)
+
+		// Check deadline again after sleep
+		select {
+		case <-deadline:
+			return errPollTimeout
+		default:
+		}
+
+		done, err := check()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+	}
+}
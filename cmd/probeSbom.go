@@ -21,7 +21,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -205,7 +204,7 @@ func probeSbomFunc() {
 	sbomBytes, err := os.ReadFile(infile)
 	if err != nil {
 		fmt.Println("Error reading SBOM file:", err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 	sbomContent := string(sbomBytes)
 
@@ -224,7 +223,7 @@ func probeSbomFunc() {
 			fmt.Println("Error:", err)
 			if attempt == maxRetries {
 				fmt.Println("All retries exhausted.")
-				os.Exit(1)
+				exitCommand(1)
 			}
 		}
 	}
@@ -269,37 +268,9 @@ func runProbeAttempt(sbomContent string) error {
 		fmt.Println("Probing run ID:", probingRun.RunId, "initial status:", probingRun.Status)
 	}
 
-	// Step 2: Spinner goroutine with dynamic message
-	done := make(chan struct{})
-	var once sync.Once
-	stopSpinner := func() {
-		once.Do(func() {
-			close(done)
-			time.Sleep(200 * time.Millisecond) // let goroutine clear the line
-		})
-	}
-	defer stopSpinner()
-
-	var spinnerMsg = "Analyzing..."
-	var spinnerMu sync.Mutex
-
-	go func() {
-		symbols := []string{"|", "/", "-", "\\"}
-		i := 0
-		for {
-			select {
-			case <-done:
-				fmt.Print("\r                                                    \r")
-				return
-			case <-time.After(1 * time.Second):
-				spinnerMu.Lock()
-				msg := spinnerMsg
-				spinnerMu.Unlock()
-				fmt.Printf("\r%-50s %s", msg, symbols[i%4])
-				i++
-			}
-		}
-	}()
+	// Step 2: Spinner with dynamic message
+	spinner := startProgressSpinner(os.Stdout, "Analyzing...")
+	defer spinner.Stop()
 
 	// Step 3: Poll every 10 seconds with 60-minute deadline
 	pollQuery := `
@@ -363,103 +334,84 @@ func runProbeAttempt(sbomContent string) error {
 		"runId": probingRun.RunId,
 	}
 
-	deadline := time.After(timeoutPerAttempt)
-
-	for {
-		// Check deadline before sleeping
-		select {
-		case <-deadline:
-			stopSpinner()
-			return fmt.Errorf("timed out after 60 minutes waiting for probing result")
-		default:
-		}
-
-		time.Sleep(10 * time.Second)
-
-		// Check deadline again after sleep
-		select {
-		case <-deadline:
-			stopSpinner()
-			return fmt.Errorf("timed out after 60 minutes waiting for probing result")
-		default:
-		}
-
+	var probingResult SbomProbingResult
+	err = pollUntil(10*time.Second, timeoutPerAttempt, func() (bool, error) {
 		pollData, err := sendGraphQLRequest(pollQuery, pollVars, rearmUri+"/graphql")
 		if err != nil {
-			stopSpinner()
-			return fmt.Errorf("poll failed: %w", err)
+			return false, fmt.Errorf("poll failed: %w", err)
 		}
 
 		resultRaw, ok := pollData["getSbomProbingResult"]
 		if !ok {
-			stopSpinner()
-			return fmt.Errorf("unexpected response from getSbomProbingResult")
+			return false, fmt.Errorf("unexpected response from getSbomProbingResult")
 		}
 
 		resultBytes, _ := json.Marshal(resultRaw)
-		var probingResult SbomProbingResult
 		if err := json.Unmarshal(resultBytes, &probingResult); err != nil {
-			stopSpinner()
-			return fmt.Errorf("error parsing probing result: %w", err)
+			return false, fmt.Errorf("error parsing probing result: %w", err)
 		}
 
 		switch probingResult.Status {
 		case "DONE":
-			stopSpinner()
-			if probingResult.Metrics == nil {
-				fmt.Println("{}")
-				return nil
-			}
-			if debug == "true" {
-				metricsJSON, _ := json.MarshalIndent(probingResult.Metrics, "", "  ")
-				fmt.Println(string(metricsJSON))
-			} else {
-				out := SbomMetricsIntOutput{
-					DtrackSubmissionAttempts:             probingResult.Metrics.DtrackSubmissionAttempts,
-					Critical:                             probingResult.Metrics.Critical,
-					High:                                 probingResult.Metrics.High,
-					Medium:                               probingResult.Metrics.Medium,
-					Low:                                  probingResult.Metrics.Low,
-					Unassigned:                           probingResult.Metrics.Unassigned,
-					Vulnerabilities:                      probingResult.Metrics.Vulnerabilities,
-					VulnerableComponents:                 probingResult.Metrics.VulnerableComponents,
-					Components:                           probingResult.Metrics.Components,
-					Suppressed:                           probingResult.Metrics.Suppressed,
-					FindingsTotal:                        probingResult.Metrics.FindingsTotal,
-					FindingsAudited:                      probingResult.Metrics.FindingsAudited,
-					FindingsUnaudited:                    probingResult.Metrics.FindingsUnaudited,
-					InheritedRiskScore:                   probingResult.Metrics.InheritedRiskScore,
-					PolicyViolationsFail:                 probingResult.Metrics.PolicyViolationsFail,
-					PolicyViolationsWarn:                 probingResult.Metrics.PolicyViolationsWarn,
-					PolicyViolationsInfo:                 probingResult.Metrics.PolicyViolationsInfo,
-					PolicyViolationsTotal:                probingResult.Metrics.PolicyViolationsTotal,
-					PolicyViolationsAudited:              probingResult.Metrics.PolicyViolationsAudited,
-					PolicyViolationsUnaudited:            probingResult.Metrics.PolicyViolationsUnaudited,
-					PolicyViolationsSecurityTotal:        probingResult.Metrics.PolicyViolationsSecurityTotal,
-					PolicyViolationsSecurityAudited:      probingResult.Metrics.PolicyViolationsSecurityAudited,
-					PolicyViolationsSecurityUnaudited:    probingResult.Metrics.PolicyViolationsSecurityUnaudited,
-					PolicyViolationsLicenseTotal:         probingResult.Metrics.PolicyViolationsLicenseTotal,
-					PolicyViolationsLicenseAudited:       probingResult.Metrics.PolicyViolationsLicenseAudited,
-					PolicyViolationsLicenseUnaudited:     probingResult.Metrics.PolicyViolationsLicenseUnaudited,
-					PolicyViolationsOperationalTotal:     probingResult.Metrics.PolicyViolationsOperationalTotal,
-					PolicyViolationsOperationalAudited:   probingResult.Metrics.PolicyViolationsOperationalAudited,
-					PolicyViolationsOperationalUnaudited: probingResult.Metrics.PolicyViolationsOperationalUnaudited,
-				}
-				outJSON, _ := json.Marshal(out)
-				fmt.Println(string(outJSON))
-			}
-			return nil
+			return true, nil
 		case "FAILED":
-			stopSpinner()
-			return fmt.Errorf("probing run failed (status: FAILED)")
+			return false, fmt.Errorf("probing run failed (status: FAILED)")
 		case "ENRICHING":
-			spinnerMu.Lock()
-			spinnerMsg = "Enriching..."
-			spinnerMu.Unlock()
+			spinner.SetMessage("Enriching...")
 		default: // PENDING or unknown
-			spinnerMu.Lock()
-			spinnerMsg = "Analyzing..."
-			spinnerMu.Unlock()
+			spinner.SetMessage("Analyzing...")
 		}
+		return false, nil
+	})
+	spinner.Stop()
+	if err == errPollTimeout {
+		return fmt.Errorf("timed out after 60 minutes waiting for probing result")
+	}
+	if err != nil {
+		return err
+	}
+
+	if probingResult.Metrics == nil {
+		fmt.Println("{}")
+		return nil
+	}
+	if debug == "true" {
+		metricsJSON, _ := json.MarshalIndent(probingResult.Metrics, "", "  ")
+		fmt.Println(string(metricsJSON))
+		return nil
+	}
+	out := SbomMetricsIntOutput{
+		DtrackSubmissionAttempts:             probingResult.Metrics.DtrackSubmissionAttempts,
+		Critical:                             probingResult.Metrics.Critical,
+		High:                                 probingResult.Metrics.High,
+		Medium:                               probingResult.Metrics.Medium,
+		Low:                                  probingResult.Metrics.Low,
+		Unassigned:                           probingResult.Metrics.Unassigned,
+		Vulnerabilities:                      probingResult.Metrics.Vulnerabilities,
+		VulnerableComponents:                 probingResult.Metrics.VulnerableComponents,
+		Components:                           probingResult.Metrics.Components,
+		Suppressed:                           probingResult.Metrics.Suppressed,
+		FindingsTotal:                        probingResult.Metrics.FindingsTotal,
+		FindingsAudited:                      probingResult.Metrics.FindingsAudited,
+		FindingsUnaudited:                    probingResult.Metrics.FindingsUnaudited,
+		InheritedRiskScore:                   probingResult.Metrics.InheritedRiskScore,
+		PolicyViolationsFail:                 probingResult.Metrics.PolicyViolationsFail,
+		PolicyViolationsWarn:                 probingResult.Metrics.PolicyViolationsWarn,
+		PolicyViolationsInfo:                 probingResult.Metrics.PolicyViolationsInfo,
+		PolicyViolationsTotal:                probingResult.Metrics.PolicyViolationsTotal,
+		PolicyViolationsAudited:              probingResult.Metrics.PolicyViolationsAudited,
+		PolicyViolationsUnaudited:            probingResult.Metrics.PolicyViolationsUnaudited,
+		PolicyViolationsSecurityTotal:        probingResult.Metrics.PolicyViolationsSecurityTotal,
+		PolicyViolationsSecurityAudited:      probingResult.Metrics.PolicyViolationsSecurityAudited,
+		PolicyViolationsSecurityUnaudited:    probingResult.Metrics.PolicyViolationsSecurityUnaudited,
+		PolicyViolationsLicenseTotal:         probingResult.Metrics.PolicyViolationsLicenseTotal,
+		PolicyViolationsLicenseAudited:       probingResult.Metrics.PolicyViolationsLicenseAudited,
+		PolicyViolationsLicenseUnaudited:     probingResult.Metrics.PolicyViolationsLicenseUnaudited,
+		PolicyViolationsOperationalTotal:     probingResult.Metrics.PolicyViolationsOperationalTotal,
+		PolicyViolationsOperationalAudited:   probingResult.Metrics.PolicyViolationsOperationalAudited,
+		PolicyViolationsOperationalUnaudited: probingResult.Metrics.PolicyViolationsOperationalUnaudited,
 	}
+	outJSON, _ := json.Marshal(out)
+	fmt.Println(string(outJSON))
+	return nil
 }
@@ -0,0 +1,169 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spf13/cobra"
+)
+
+var (
+	productBomRelease   string
+	productBomStructure string
+	productBomMergeMode string
+	productBomGroup     string
+	productBomPurl      string
+	productBomBelongsTo string
+	productBomRaw       bool
+	productBomOutfile   string
+)
+
+var productCmd = &cobra.Command{
+	Use:   "product",
+	Short: "Commands operating on product releases",
+}
+
+var productBomCmd = &cobra.Command{
+	Use:   "bom",
+	Short: "Assemble a product SBOM from the BOMs of its component releases",
+	Long: `Resolves the parent releases of a product release (following nested products),
+downloads the CycloneDX BOM artifacts of every component release and merges them with
+the merge-boms logic into one SBOM rooted at the product name and version.
+
+By default the processed BOMs stored by ReARM are merged; --raw uses the files as they
+were uploaded. BOMs that are not CycloneDX JSON are skipped with a warning.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Fprintln(os.Stderr, "Using ReARM at", rearmUri)
+		}
+		belongsTo := strings.ToUpper(productBomBelongsTo)
+		if belongsTo != "" && belongsTo != artifactOwnerDeliverable && belongsTo != artifactOwnerRelease && belongsTo != artifactOwnerSce {
+			fmt.Fprintln(os.Stderr, "Error: --belongs-to must be DELIVERABLE, RELEASE or SCE")
+			exitCommand(2)
+		}
+		var product releaseContent
+		if err := fetchReleaseContent(productBomRelease, "", &product); err != nil {
+			printGqlError(err)
+			exitCommand(1)
+		}
+		if len(product.ParentReleases) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: release %s has no parent releases, it is not a product release\n", productBomRelease)
+			exitCommand(1)
+		}
+		boms, releases, err := collectProductBoms(&product, belongsTo, !productBomRaw)
+		if err != nil {
+			printGqlError(err)
+			exitCommand(1)
+		}
+		if len(boms) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: none of the %d parent releases has a CycloneDX BOM\n", releases)
+			exitCommand(1)
+		}
+		fmt.Fprintf(os.Stderr, "Merging %d BOM(s) from %d parent release(s)\n", len(boms), releases)
+
+		merged, err := mergeBoms(boms, strings.ToUpper(productBomStructure), productBomGroup, product.ComponentDetails.Name,
+			product.Version, strings.ToUpper(productBomMergeMode), productBomPurl)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			exitCommand(1)
+		}
+		buf := new(bytes.Buffer)
+		if err := cdx.NewBOMEncoder(buf, cdx.BOMFileFormatJSON).Encode(merged); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding merged BOM: %v\n", err)
+			exitCommand(1)
+		}
+		if productBomOutfile == "" || productBomOutfile == "-" {
+			os.Stdout.Write(buf.Bytes())
+			return
+		}
+		if err := os.WriteFile(productBomOutfile, buf.Bytes(), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing to file %s: %v\n", productBomOutfile, err)
+			exitCommand(1)
+		}
+		fmt.Println(productBomOutfile)
+	},
+}
+
+// collectProductBoms walks the parent releases of a product release, descending
+// into nested products, and downloads the BOM artifacts of every component
+// release. belongsTo optionally limits the BOMs to one artifact owner. Returns
+// the parsed BOMs and the number of parent releases visited.
+func collectProductBoms(product *releaseContent, belongsTo string, processed bool) ([]*cdx.BOM, int, error) {
+	var boms []*cdx.BOM
+	releases := 0
+	seen := map[string]bool{product.Uuid: true}
+	queue := []*releaseContent{product}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, p := range current.ParentReleases {
+			parentUuid := p.Release
+			if p.ReleaseDetails != nil {
+				parentUuid = p.ReleaseDetails.Uuid
+			}
+			if parentUuid == "" || seen[parentUuid] {
+				continue
+			}
+			seen[parentUuid] = true
+			var rlz releaseContent
+			if err := fetchReleaseContent(parentUuid, "", &rlz); err != nil {
+				return nil, releases, err
+			}
+			if len(rlz.ParentReleases) > 0 {
+				queue = append(queue, &rlz)
+			}
+			releases++
+			for _, a := range rlz.allArtifacts() {
+				if a.Type != "BOM" || (belongsTo != "" && a.BelongsTo != belongsTo) {
+					continue
+				}
+				data, _, err := downloadArtifactBytes(a.Uuid, !processed, 0)
+				if err != nil {
+					return nil, releases, fmt.Errorf("downloading BOM %s of %s %s: %v", a.Uuid, rlz.ComponentDetails.Name, rlz.Version, err)
+				}
+				bom, err := parseBom(data)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: skipping BOM %s (%s) of %s %s: %v\n", a.DisplayIdentifier, a.Uuid, rlz.ComponentDetails.Name, rlz.Version, err)
+					continue
+				}
+				boms = append(boms, bom)
+			}
+		}
+	}
+	return boms, releases, nil
+}
+
+func init() {
+	productBomCmd.PersistentFlags().StringVar(&productBomRelease, "release", "", "UUID of the product release")
+	productBomCmd.MarkPersistentFlagRequired("release")
+	productBomCmd.PersistentFlags().StringVar(&productBomStructure, "structure", "HIERARCHICAL", "Structure of the merged BOM (FLAT, HIERARCHICAL)")
+	productBomCmd.PersistentFlags().StringVar(&productBomMergeMode, "root-component-merge-mode", "PRESERVE_UNDER_NEW_ROOT", "Root component merge mode (PRESERVE_UNDER_NEW_ROOT, FLATTEN_UNDER_NEW_ROOT)")
+	productBomCmd.PersistentFlags().StringVar(&productBomGroup, "group", "", "Group of the product root component (optional)")
+	productBomCmd.PersistentFlags().StringVar(&productBomPurl, "purl", "", "Set bom-ref and purl for the product root component (optional)")
+	productBomCmd.PersistentFlags().StringVar(&productBomBelongsTo, "belongs-to", "", "Only merge BOMs attached to DELIVERABLE, RELEASE or SCE (optional, default all)")
+	productBomCmd.PersistentFlags().BoolVar(&productBomRaw, "raw", false, "Merge the BOMs as uploaded instead of the processed ones (optional)")
+	productBomCmd.PersistentFlags().StringVar(&productBomOutfile, "outfile", "", "Output file path to write the product BOM (default: stdout)")
+
+	productCmd.AddCommand(productBomCmd)
+	rootCmd.AddCommand(productCmd)
+}
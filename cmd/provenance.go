@@ -0,0 +1,567 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/spf13/cobra"
+)
+
+const (
+	inTotoStatementType    = "https://in-toto.io/Statement/v1"
+	inTotoPayloadType      = "application/vnd.in-toto+json"
+	slsaProvenanceType     = "https://slsa.dev/provenance/v1"
+	genericCiBuildType     = "https://reliza.io/rearm/buildtypes/ci/v1"
+	githubActionsBuildType = "https://actions.github.io/buildtypes/workflow/v1"
+)
+
+var (
+	attestRelease      string
+	attestComponent    string
+	attestVersion      string
+	attestDeliverable  string
+	attestSubjects     []string
+	attestSubjectFiles []string
+	attestBuilderId    string
+	attestSigningKey   string
+	attestKeyId        string
+	attestOutfile      string
+	attestNoUpload     bool
+)
+
+var attestCmd = &cobra.Command{
+	Use:   "attest",
+	Short: "Generate attestations for releases",
+}
+
+var attestProvenanceCmd = &cobra.Command{
+	Use:   "provenance",
+	Short: "Generate a SLSA v1 provenance statement and attach it to a release",
+	Long: `Builds an in-toto SLSA v1 provenance statement for a release. The builder and
+invocation are detected from the CI environment (GitHub Actions, GitLab CI, Jenkins,
+Azure Pipelines, Bitbucket Pipelines, CircleCI), subjects are the digests of the
+release deliverables and the source commit is taken from the release source code
+entry.
+
+With --signing-key the statement is wrapped in a signed DSSE envelope. The result is
+written to --outfile and attached to the release, or to the deliverable given with
+--deliverable, as an ATTESTATION artifact unless --no-upload is set.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Fprintln(os.Stderr, "Using ReARM at", rearmUri)
+		}
+		releaseUuid := attestRelease
+		if releaseUuid == "" {
+			if attestComponent == "" || attestVersion == "" {
+				fmt.Fprintln(os.Stderr, "Error: either --release or --component and --version must be specified")
+				exitCommand(2)
+			}
+			var err error
+			releaseUuid, err = resolveReleaseUuidByVersion(attestComponent, attestVersion)
+			if err != nil {
+				printGqlError(err)
+				exitCommand(1)
+			}
+			if releaseUuid == "" {
+				fmt.Fprintf(os.Stderr, "Error: release %s of component %s not found\n", attestVersion, attestComponent)
+				exitCommand(1)
+			}
+		}
+
+		rlz, err := fetchProvenanceRelease(releaseUuid)
+		if err != nil {
+			printGqlError(err)
+			exitCommand(1)
+		}
+
+		deliverableUuid, err := resolveAttestDeliverable(rlz, attestDeliverable)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			exitCommand(1)
+		}
+
+		statement, err := buildProvenance(rlz, detectCiEnvironment(), deliverableUuid, attestSubjects, attestSubjectFiles, attestBuilderId)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			exitCommand(1)
+		}
+		payload, _ := json.MarshalIndent(statement, "", "  ")
+		if attestSigningKey != "" {
+			key, err := loadSigningKey(attestSigningKey, attestKeyId)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error loading signing key:", err)
+				exitCommand(1)
+			}
+			envelope, err := signDsse(inTotoPayloadType, payload, key)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error signing provenance:", err)
+				exitCommand(1)
+			}
+			payload, _ = json.MarshalIndent(envelope, "", "  ")
+		}
+
+		outPath := attestOutfile
+		if outPath == "" {
+			dir, err := os.MkdirTemp("", "rearm-attest")
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err)
+				exitCommand(1)
+			}
+			defer os.RemoveAll(dir)
+			outPath = filepath.Join(dir, "provenance.intoto.json")
+		}
+		if err := os.WriteFile(outPath, payload, 0644); err != nil {
+			fmt.Fprintln(os.Stderr, "Error writing file:", err)
+			exitCommand(1)
+		}
+		if attestNoUpload {
+			if attestOutfile == "" {
+				fmt.Println(string(payload))
+			} else {
+				fmt.Println(outPath)
+			}
+			return
+		}
+
+		resp, err := attachAttestation(releaseUuid, deliverableUuid, outPath)
+		if resp == nil {
+			fmt.Fprintln(os.Stderr, "Error sending request:", err)
+			exitCommand(1)
+		}
+		handleResponse(err, resp)
+	},
+}
+
+const PROVENANCE_GQL_DATA = `
+	variantDetails {
+		outboundDeliverableDetails {
+			softwareMetadata {
+				buildId
+				buildUri
+				cicdMeta
+				dateFrom
+				dateTo
+			}
+		}
+	}
+`
+
+// provenanceRelease is a release together with the build metadata of its
+// deliverables and its VCS repository.
+type provenanceRelease struct {
+	releaseContent
+	VcsRepository *struct {
+		Uri  string `json:"uri"`
+		Type string `json:"type"`
+	}
+	Builds map[string]deliverableBuild
+}
+
+type deliverableBuild struct {
+	BuildId  string `json:"buildId"`
+	BuildUri string `json:"buildUri"`
+	CicdMeta string `json:"cicdMeta"`
+	DateFrom string `json:"dateFrom"`
+	DateTo   string `json:"dateTo"`
+}
+
+func fetchProvenanceRelease(releaseUuid string) (*provenanceRelease, error) {
+	raw, err := fetchRelease(releaseUuid, RELEASE_CONTENT_GQL_DATA+PROVENANCE_GQL_DATA)
+	if err != nil {
+		return nil, err
+	}
+	rlz := &provenanceRelease{Builds: map[string]deliverableBuild{}}
+	if err := decodeInto(raw, &rlz.releaseContent); err != nil {
+		return nil, err
+	}
+	var extra struct {
+		VcsRepository *struct {
+			Uri  string `json:"uri"`
+			Type string `json:"type"`
+		} `json:"vcsRepository"`
+		VariantDetails []struct {
+			OutboundDeliverableDetails []struct {
+				Uuid             string           `json:"uuid"`
+				SoftwareMetadata deliverableBuild `json:"softwareMetadata"`
+			} `json:"outboundDeliverableDetails"`
+		} `json:"variantDetails"`
+	}
+	if err := decodeInto(raw, &extra); err != nil {
+		return nil, err
+	}
+	rlz.VcsRepository = extra.VcsRepository
+	for _, v := range extra.VariantDetails {
+		for _, d := range v.OutboundDeliverableDetails {
+			rlz.Builds[d.Uuid] = d.SoftwareMetadata
+		}
+	}
+	return rlz, nil
+}
+
+// ciEnvironment is what the CLI can tell about the build it runs in.
+type ciEnvironment struct {
+	Name         string
+	BuilderId    string
+	BuildType    string
+	InvocationId string
+	RepoUri      string
+	Commit       string
+	Ref          string
+	Parameters   map[string]interface{}
+}
+
+// detectCiEnvironment inspects well-known environment variables of the
+// supported CI systems. Outside CI it returns a local builder.
+func detectCiEnvironment() ciEnvironment {
+	env := os.Getenv
+	switch {
+	case env("GITHUB_ACTIONS") == "true":
+		server := env("GITHUB_SERVER_URL")
+		repo := server + "/" + env("GITHUB_REPOSITORY")
+		builder := server + "/actions/runner"
+		if wf := env("GITHUB_WORKFLOW_REF"); wf != "" {
+			builder = server + "/" + wf
+		}
+		workflowPath := env("GITHUB_WORKFLOW_REF")
+		if i := strings.Index(workflowPath, "/.github/"); i >= 0 {
+			workflowPath = workflowPath[i+1:]
+		}
+		if i := strings.Index(workflowPath, "@"); i >= 0 {
+			workflowPath = workflowPath[:i]
+		}
+		return ciEnvironment{
+			Name:         "github",
+			BuilderId:    builder,
+			BuildType:    githubActionsBuildType,
+			InvocationId: repo + "/actions/runs/" + env("GITHUB_RUN_ID") + "/attempts/" + env("GITHUB_RUN_ATTEMPT"),
+			RepoUri:      repo,
+			Commit:       env("GITHUB_SHA"),
+			Ref:          env("GITHUB_REF"),
+			Parameters: map[string]interface{}{
+				"workflow": map[string]string{
+					"ref":        env("GITHUB_REF"),
+					"repository": repo,
+					"path":       workflowPath,
+				},
+			},
+		}
+	case env("GITLAB_CI") == "true":
+		return ciEnvironment{
+			Name:         "gitlab",
+			BuilderId:    env("CI_SERVER_URL") + "/" + env("CI_PROJECT_PATH") + "/-/runners/" + env("CI_RUNNER_ID"),
+			BuildType:    genericCiBuildType,
+			InvocationId: env("CI_JOB_URL"),
+			RepoUri:      env("CI_PROJECT_URL"),
+			Commit:       env("CI_COMMIT_SHA"),
+			Ref:          env("CI_COMMIT_REF_NAME"),
+			Parameters:   map[string]interface{}{"pipeline": env("CI_PIPELINE_URL"), "job": env("CI_JOB_NAME")},
+		}
+	case env("TF_BUILD") == "True" || env("TF_BUILD") == "true":
+		return ciEnvironment{
+			Name:         "azure",
+			BuilderId:    env("SYSTEM_COLLECTIONURI") + env("SYSTEM_TEAMPROJECT") + "/_build?definitionId=" + env("SYSTEM_DEFINITIONID"),
+			BuildType:    genericCiBuildType,
+			InvocationId: env("SYSTEM_COLLECTIONURI") + env("SYSTEM_TEAMPROJECT") + "/_build/results?buildId=" + env("BUILD_BUILDID"),
+			RepoUri:      env("BUILD_REPOSITORY_URI"),
+			Commit:       env("BUILD_SOURCEVERSION"),
+			Ref:          env("BUILD_SOURCEBRANCH"),
+			Parameters:   map[string]interface{}{"pipeline": env("BUILD_DEFINITIONNAME")},
+		}
+	case env("BITBUCKET_BUILD_NUMBER") != "":
+		origin := env("BITBUCKET_GIT_HTTP_ORIGIN")
+		return ciEnvironment{
+			Name:         "bitbucket",
+			BuilderId:    "https://bitbucket.org/" + env("BITBUCKET_REPO_FULL_NAME") + "/pipelines",
+			BuildType:    genericCiBuildType,
+			InvocationId: origin + "/pipelines/results/" + env("BITBUCKET_BUILD_NUMBER"),
+			RepoUri:      origin,
+			Commit:       env("BITBUCKET_COMMIT"),
+			Ref:          env("BITBUCKET_BRANCH"),
+		}
+	case env("CIRCLECI") == "true":
+		return ciEnvironment{
+			Name:         "circleci",
+			BuilderId:    "https://circleci.com/gh/" + env("CIRCLE_PROJECT_USERNAME") + "/" + env("CIRCLE_PROJECT_REPONAME"),
+			BuildType:    genericCiBuildType,
+			InvocationId: env("CIRCLE_BUILD_URL"),
+			RepoUri:      env("CIRCLE_REPOSITORY_URL"),
+			Commit:       env("CIRCLE_SHA1"),
+			Ref:          env("CIRCLE_BRANCH"),
+			Parameters:   map[string]interface{}{"job": env("CIRCLE_JOB")},
+		}
+	case env("JENKINS_URL") != "":
+		return ciEnvironment{
+			Name:         "jenkins",
+			BuilderId:    env("JENKINS_URL"),
+			BuildType:    genericCiBuildType,
+			InvocationId: env("BUILD_URL"),
+			RepoUri:      env("GIT_URL"),
+			Commit:       env("GIT_COMMIT"),
+			Ref:          env("GIT_BRANCH"),
+			Parameters:   map[string]interface{}{"job": env("JOB_NAME")},
+		}
+	}
+	host, _ := os.Hostname()
+	return ciEnvironment{
+		Name:      "local",
+		BuilderId: "local://" + host,
+		BuildType: genericCiBuildType,
+	}
+}
+
+// InTotoStatement is an in-toto v1 statement.
+type InTotoStatement struct {
+	Type          string          `json:"_type"`
+	Subject       []InTotoSubject `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     interface{}     `json:"predicate"`
+}
+
+type InTotoSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+type slsaResourceDescriptor struct {
+	Uri    string            `json:"uri"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+type slsaProvenance struct {
+	BuildDefinition struct {
+		BuildType            string                   `json:"buildType"`
+		ExternalParameters   map[string]interface{}   `json:"externalParameters"`
+		InternalParameters   map[string]interface{}   `json:"internalParameters,omitempty"`
+		ResolvedDependencies []slsaResourceDescriptor `json:"resolvedDependencies,omitempty"`
+	} `json:"buildDefinition"`
+	RunDetails struct {
+		Builder struct {
+			Id string `json:"id"`
+		} `json:"builder"`
+		Metadata struct {
+			InvocationId string `json:"invocationId,omitempty"`
+			StartedOn    string `json:"startedOn,omitempty"`
+			FinishedOn   string `json:"finishedOn,omitempty"`
+		} `json:"metadata"`
+	} `json:"runDetails"`
+}
+
+// buildProvenance assembles the SLSA statement. Subjects are the digests of
+// the release deliverables, or only of the deliverable UUID when set, plus any
+// explicit subjects and local files.
+func buildProvenance(rlz *provenanceRelease, ci ciEnvironment, deliverable string, subjects []string, subjectFiles []string, builderId string) (*InTotoStatement, error) {
+	var pred slsaProvenance
+	statement := &InTotoStatement{
+		Type:          inTotoStatementType,
+		Subject:       []InTotoSubject{},
+		PredicateType: slsaProvenanceType,
+		Predicate:     &pred,
+	}
+
+	for _, d := range rlz.deliverables() {
+		if deliverable != "" && d.Uuid != deliverable {
+			continue
+		}
+		if s, ok := deliverableSubject(d); ok {
+			statement.Subject = append(statement.Subject, s)
+		}
+	}
+	for _, s := range subjects {
+		name, digest, ok := strings.Cut(s, "=")
+		algo, value, hasAlgo := strings.Cut(digest, ":")
+		if !ok || !hasAlgo || value == "" {
+			return nil, fmt.Errorf("--subject must be in name=algo:digest form, got %q", s)
+		}
+		statement.Subject = append(statement.Subject, InTotoSubject{Name: name, Digest: map[string]string{strings.ToLower(algo): strings.ToLower(value)}})
+	}
+	for _, f := range subjectFiles {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		digests := map[string]string{}
+		for _, dr := range computeDigestRecords(b) {
+			algo, value, _ := strings.Cut(dr, ":")
+			digests[algo] = value
+		}
+		statement.Subject = append(statement.Subject, InTotoSubject{Name: filepath.Base(f), Digest: digests})
+	}
+	if len(statement.Subject) == 0 {
+		return nil, fmt.Errorf("no subjects: release %s has no deliverable digests, use --subject or --subject-file", rlz.Uuid)
+	}
+
+	def := &pred.BuildDefinition
+	def.BuildType = ci.BuildType
+	def.ExternalParameters = ci.Parameters
+	if def.ExternalParameters == nil {
+		def.ExternalParameters = map[string]interface{}{}
+	}
+	def.InternalParameters = map[string]interface{}{
+		"rearm": map[string]string{
+			"release":   rlz.Uuid,
+			"component": rlz.ComponentDetails.Name,
+			"version":   rlz.Version,
+		},
+	}
+
+	repo := ci.RepoUri
+	if rlz.VcsRepository != nil && rlz.VcsRepository.Uri != "" {
+		repo = rlz.VcsRepository.Uri
+	}
+	commit := ci.Commit
+	if rlz.SourceCodeEntryDetails != nil && rlz.SourceCodeEntryDetails.Commit != "" {
+		commit = rlz.SourceCodeEntryDetails.Commit
+	}
+	if repo != "" && commit != "" {
+		uri := repo
+		if !strings.Contains(uri, "://") {
+			uri = "https://" + uri
+		}
+		uri = "git+" + uri
+		if ci.Ref != "" {
+			uri += "@" + ci.Ref
+		}
+		def.ResolvedDependencies = append(def.ResolvedDependencies, slsaResourceDescriptor{Uri: uri, Digest: map[string]string{"gitCommit": commit}})
+	}
+
+	run := &pred.RunDetails
+	run.Builder.Id = ci.BuilderId
+	if builderId != "" {
+		run.Builder.Id = builderId
+	}
+	run.Metadata.InvocationId = ci.InvocationId
+	for _, d := range rlz.deliverables() {
+		if deliverable != "" && d.Uuid != deliverable {
+			continue
+		}
+		md := rlz.Builds[d.Uuid]
+		if run.Metadata.InvocationId == "" {
+			run.Metadata.InvocationId = md.BuildUri
+		}
+		if run.Metadata.StartedOn == "" {
+			run.Metadata.StartedOn = md.DateFrom
+		}
+		if run.Metadata.FinishedOn == "" {
+			run.Metadata.FinishedOn = md.DateTo
+		}
+		if md.BuildId != "" {
+			def.InternalParameters["buildId"] = md.BuildId
+		}
+		if md.CicdMeta != "" {
+			def.InternalParameters["cicdMeta"] = md.CicdMeta
+		}
+	}
+	if run.Metadata.FinishedOn == "" {
+		run.Metadata.FinishedOn = time.Now().UTC().Format(time.RFC3339)
+	}
+	return statement, nil
+}
+
+// resolveAttestDeliverable maps --deliverable, a UUID or display identifier,
+// to the UUID of the matching release deliverable. Returns an empty string
+// when no deliverable is given.
+func resolveAttestDeliverable(rlz *provenanceRelease, deliverable string) (string, error) {
+	if deliverable == "" {
+		return "", nil
+	}
+	var matches []string
+	for _, d := range rlz.deliverables() {
+		if d.Uuid == deliverable {
+			return d.Uuid, nil
+		}
+		if d.DisplayIdentifier == deliverable {
+			matches = append(matches, d.Uuid)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("deliverable %s not found on release %s", deliverable, rlz.Uuid)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("deliverable %s is ambiguous on release %s, use its UUID", deliverable, rlz.Uuid)
+	}
+}
+
+// deliverableSubject converts the recorded digests of a deliverable, e.g.
+// sha256:<hex>, into an in-toto subject.
+func deliverableSubject(d releaseDeliverable) (InTotoSubject, bool) {
+	digests := map[string]string{}
+	for _, dg := range d.SoftwareMetadata.Digests {
+		algo, value, ok := strings.Cut(strings.TrimSpace(dg), ":")
+		if !ok {
+			continue
+		}
+		digests[strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(algo))] = strings.ToLower(value)
+	}
+	if len(digests) == 0 {
+		return InTotoSubject{}, false
+	}
+	name := d.DisplayIdentifier
+	if name == "" {
+		name = d.Uuid
+	}
+	return InTotoSubject{Name: name, Digest: digests}, true
+}
+
+// attachAttestation uploads the file at path as an ATTESTATION artifact of
+// the release, or of the deliverable when set.
+func attachAttestation(releaseUuid string, deliverable string, path string) (*resty.Response, error) {
+	arts := []Artifact{{
+		DisplayIdentifier: "slsa-provenance",
+		Type:              "ATTESTATION",
+		StoredIn:          "REARM",
+		FilePath:          path,
+		Tags:              []TagInput{{Key: "predicateType", Value: slsaProvenanceType}},
+	}}
+	filesCounter := 0
+	locationMap := make(map[string][]string)
+	filesMap := make(map[string]interface{})
+	artifactInput := map[string]interface{}{"release": releaseUuid}
+	if deliverable == "" {
+		artifactInput["releaseArtifacts"] = processArtifactsInput(&arts, "variables.artifactInput.releaseArtifacts.", &filesCounter, &locationMap, &filesMap)
+	} else {
+		processed := processArtifactsInput(&arts, "variables.artifactInput.deliverableArtifacts.0.artifacts.", &filesCounter, &locationMap, &filesMap)
+		artifactInput["deliverableArtifacts"] = []DeliverableArtifactGroup{{Deliverable: deliverable, Artifacts: *processed}}
+	}
+	return postAddArtifact(map[string]interface{}{"artifactInput": artifactInput}, locationMap, filesMap)
+}
+
+func init() {
+	attestProvenanceCmd.PersistentFlags().StringVar(&attestRelease, "release", "", "UUID of the release")
+	attestProvenanceCmd.PersistentFlags().StringVar(&attestComponent, "component", "", "Component UUID, used with --version instead of --release")
+	attestProvenanceCmd.PersistentFlags().StringVar(&attestVersion, "version", "", "Release version, used with --component")
+	attestProvenanceCmd.PersistentFlags().StringVar(&attestDeliverable, "deliverable", "", "(Optional) UUID or display identifier of the deliverable to attest and attach to; default is all deliverables, attached to the release")
+	attestProvenanceCmd.PersistentFlags().StringArrayVar(&attestSubjects, "subject", []string{}, "(Optional) Extra subject in name=algo:digest form, repeatable")
+	attestProvenanceCmd.PersistentFlags().StringArrayVar(&attestSubjectFiles, "subject-file", []string{}, "(Optional) Local file to add as a subject, repeatable")
+	attestProvenanceCmd.PersistentFlags().StringVar(&attestBuilderId, "builder-id", "", "(Optional) Override the detected builder id")
+	attestProvenanceCmd.PersistentFlags().StringVar(&attestSigningKey, "signing-key", "", "(Optional) PEM ed25519 or ECDSA private key to sign the statement with")
+	attestProvenanceCmd.PersistentFlags().StringVar(&attestKeyId, "key-id", "", "(Optional) Key id recorded in the signature (default: sha256 of the public key)")
+	attestProvenanceCmd.PersistentFlags().StringVar(&attestOutfile, "outfile", "", "(Optional) Also keep the statement in this file")
+	attestProvenanceCmd.PersistentFlags().BoolVar(&attestNoUpload, "no-upload", false, "(Optional) Only generate the statement, do not attach it to ReARM")
+
+	attestCmd.AddCommand(attestProvenanceCmd)
+	rootCmd.AddCommand(attestCmd)
+}
@@ -20,6 +20,9 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -41,6 +44,49 @@ var (
 	prUpsertCommit           string
 )
 
+// Flags for pullrequest list / show / close.
+var (
+	prListComponent  string
+	prListBranch     string
+	prListVcsUri     string
+	prListOrg        string
+	prListState      string
+	prShowNumber     int
+	prShowIdentity   string
+	prShowNumRecords int
+	prCloseMerged    bool
+)
+
+const PULL_REQUEST_GQL_DATA = `
+	number
+	state
+	title
+	targetBranch
+	endpoint
+	createdDate
+	closedDate
+	mergedDate
+	commits
+`
+
+// PullRequestEntry is a pull request together with the branch it was
+// listed on.
+type PullRequestEntry struct {
+	Component    string        `json:"component,omitempty"`
+	Branch       string        `json:"branch"`
+	BranchName   string        `json:"branchName"`
+	Number       int           `json:"number"`
+	State        string        `json:"state"`
+	Title        string        `json:"title,omitempty"`
+	TargetBranch string        `json:"targetBranch,omitempty"`
+	Endpoint     string        `json:"endpoint,omitempty"`
+	CreatedDate  string        `json:"createdDate,omitempty"`
+	ClosedDate   string        `json:"closedDate,omitempty"`
+	MergedDate   string        `json:"mergedDate,omitempty"`
+	Commits      []string      `json:"commits,omitempty"`
+	Releases     []interface{} `json:"releases,omitempty"`
+}
+
 var pullRequestCmd = &cobra.Command{
 	Use:   "pullrequest",
 	Short: "Manage ReARM PullRequest entities",
@@ -112,28 +158,259 @@ run (e.g. once per component in a monorepo).`,
 		// keys — but the CLI doesn't introspect the key type, so let
 		// the server return its own clear error rather than guessing.
 
+		fmt.Println(upsertPullRequest(input))
+	},
+}
+
+var pullRequestListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List pull requests recorded on the branches of a component or VCS repository",
+	Long: `Lists the pull requests ReARM has recorded on the branches of a component, on a
+single branch with --branch, or on the branches of every component of the VCS repository
+given with --vcsuri and --org. Use --state to only show OPEN, CLOSED or MERGED pull requests.`,
+	Run: func(cmd *cobra.Command, args []string) {
 		if debug == "true" {
-			jsonBody, _ := json.Marshal(input)
-			fmt.Println("Request input =", string(jsonBody))
+			fmt.Println("Using ReARM instance at", rearmUri)
+		}
+		var prs []PullRequestEntry
+		var err error
+		if prListVcsUri != "" && prListComponent == "" && prListBranch == "" {
+			if prListOrg == "" {
+				fmt.Fprintln(os.Stderr, "Error: --org must be set with --vcsuri")
+				exitCommand(2)
+			}
+			prs, err = listPullRequestsOfVcs(prListOrg, prListVcsUri)
+		} else {
+			prs, err = listPullRequests(prListComponent, prListBranch)
 		}
+		if err != nil {
+			printGqlError(err)
+			exitCommand(1)
+		}
+		state := strings.ToUpper(prListState)
+		filtered := []PullRequestEntry{}
+		for _, pr := range prs {
+			if state == "" || strings.ToUpper(pr.State) == state {
+				filtered = append(filtered, pr)
+			}
+		}
+		emitJson(filtered)
+	},
+}
+
+var pullRequestShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show a pull request and the releases built for it",
+	Long: `Shows the pull request with --number or --identity on the branches of --component
+(or on --branch) together with the releases built for it, as returned by the
+pullRequestFilter of the releases query.
 
+ReARM records and filters pull requests by number, so --identity must be numeric, as
+GitHub PR numbers and GitLab MR iids are. Identities such as Gerrit change-ids can be
+closed with pullrequest close but not shown.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM instance at", rearmUri)
+		}
+		if (prShowNumber == 0) == (prShowIdentity == "") {
+			fmt.Fprintln(os.Stderr, "Error: exactly one of --number or --identity must be set")
+			exitCommand(2)
+		}
+		if prShowIdentity != "" {
+			n, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(prShowIdentity), "#"))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: pull requests are looked up by number, --identity %q is not numeric\n", prShowIdentity)
+				exitCommand(2)
+			}
+			prShowNumber = n
+		}
+		prs, err := listPullRequests(prListComponent, prListBranch)
+		if err != nil {
+			printGqlError(err)
+			exitCommand(1)
+		}
+		var found *PullRequestEntry
+		for i := range prs {
+			if prs[i].Number == prShowNumber {
+				found = &prs[i]
+				break
+			}
+		}
+		if found == nil {
+			fmt.Fprintf(os.Stderr, "Error: pull request %d not found\n", prShowNumber)
+			exitCommand(1)
+		}
 		query := `
-			mutation upsertPullRequestProgrammatic($input: PullRequestUpsertProgrammaticInput!) {
-				upsertPullRequestProgrammatic(input: $input) {
-					uuid
-					identity
-					state
-					title
-					targetVcsRepository
-					commits
-				}
+			query ($branchFilter: ID, $pullRequestFilter: Int, $numRecords: Int) {
+				releases(branchFilter: $branchFilter, pullRequestFilter: $pullRequestFilter, numRecords: $numRecords) {` + RELEASE_GQL_DATA + `}
 			}
 		`
-		variables := map[string]interface{}{"input": input}
-		fmt.Println(sendRequest(query, variables, "upsertPullRequestProgrammatic"))
+		variables := map[string]interface{}{
+			"branchFilter":      found.Branch,
+			"pullRequestFilter": prShowNumber,
+			"numRecords":        prShowNumRecords,
+		}
+		data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
+		if err != nil {
+			printGqlError(err)
+			exitCommand(1)
+		}
+		found.Releases, _ = data["releases"].([]interface{})
+		if found.Releases == nil {
+			found.Releases = []interface{}{}
+		}
+		emitJson(found)
 	},
 }
 
+var pullRequestCloseCmd = &cobra.Command{
+	Use:   "close",
+	Short: "Mark a pull request as closed or merged",
+	Long: `Marks a pull request CLOSED, or MERGED with --merged, for pipelines where the SCM
+webhook is not wired up. The pull request is given with --identity, optionally with --title
+and --endpoint, and the target VCS is resolved from --component or --vcsuri the same way as
+pullrequest upsert.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM instance at", rearmUri)
+		}
+		prState = "CLOSED"
+		if prCloseMerged {
+			prState = "MERGED"
+		}
+		input := buildPullRequestInfoBody()
+		if prUpsertComponent != "" {
+			input["component"] = prUpsertComponent
+		}
+		if prUpsertVcsUri != "" {
+			input["vcsUri"] = prUpsertVcsUri
+		}
+		if prUpsertRepoPath != "" {
+			input["repoPath"] = prUpsertRepoPath
+		}
+		fmt.Println(upsertPullRequest(input))
+	},
+}
+
+// listPullRequests returns the pull requests of a single branch when branch
+// is set, otherwise of every branch of the component.
+func listPullRequests(component string, branch string) ([]PullRequestEntry, error) {
+	var branches []struct {
+		Uuid         string             `json:"uuid"`
+		Name         string             `json:"name"`
+		PullRequests []PullRequestEntry `json:"pullRequests"`
+	}
+	if branch != "" {
+		data, err := sendGraphQLRequest(`
+			query ($branchUuid: ID!) {
+				branch(branchUuid: $branchUuid) { uuid name pullRequests {`+PULL_REQUEST_GQL_DATA+`} }
+			}
+		`, map[string]interface{}{"branchUuid": branch}, rearmUri+"/graphql")
+		if err != nil {
+			return nil, err
+		}
+		if data["branch"] == nil {
+			return nil, fmt.Errorf("branch %s not found", branch)
+		}
+		if err := decodeInto([]interface{}{data["branch"]}, &branches); err != nil {
+			return nil, err
+		}
+	} else {
+		if component == "" {
+			fmt.Fprintln(os.Stderr, "Error: either --component or --branch must be set")
+			exitCommand(2)
+		}
+		data, err := sendGraphQLRequest(`
+			query ($componentUuid: ID!) {
+				branchesOfComponent(componentUuid: $componentUuid) { uuid name pullRequests {`+PULL_REQUEST_GQL_DATA+`} }
+			}
+		`, map[string]interface{}{"componentUuid": component}, rearmUri+"/graphql")
+		if err != nil {
+			return nil, err
+		}
+		if err := decodeInto(data["branchesOfComponent"], &branches); err != nil {
+			return nil, err
+		}
+	}
+	prs := []PullRequestEntry{}
+	for _, b := range branches {
+		for _, pr := range b.PullRequests {
+			pr.Branch = b.Uuid
+			pr.BranchName = b.Name
+			prs = append(prs, pr)
+		}
+	}
+	return prs, nil
+}
+
+// listPullRequestsOfVcs returns the pull requests recorded on the branches of
+// every component of the organization whose VCS repository matches uri. A
+// monorepo has several such components.
+func listPullRequestsOfVcs(org string, uri string) ([]PullRequestEntry, error) {
+	data, err := sendGraphQLRequest(`
+		query ($orgUuid: ID!, $componentType: ComponentType!) {
+			components(orgUuid: $orgUuid, componentType: $componentType) { uuid vcsRepositoryDetails { uri } }
+		}
+	`, map[string]interface{}{"orgUuid": org, "componentType": "ANY"}, rearmUri+"/graphql")
+	if err != nil {
+		return nil, err
+	}
+	var comps []struct {
+		Uuid                 string `json:"uuid"`
+		VcsRepositoryDetails *struct {
+			Uri string `json:"uri"`
+		} `json:"vcsRepositoryDetails"`
+	}
+	if err := decodeInto(data["components"], &comps); err != nil {
+		return nil, err
+	}
+	wantUri := NormalizeVcsUri(uri)
+	found := false
+	prs := []PullRequestEntry{}
+	for _, c := range comps {
+		if c.VcsRepositoryDetails == nil || NormalizeVcsUri(c.VcsRepositoryDetails.Uri) != wantUri {
+			continue
+		}
+		found = true
+		componentPrs, err := listPullRequests(c.Uuid, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, pr := range componentPrs {
+			pr.Component = c.Uuid
+			prs = append(prs, pr)
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("no component found for VCS repository %s", uri)
+	}
+	return prs, nil
+}
+
+// upsertPullRequest sends upsertPullRequestProgrammatic and returns the
+// resulting PullRequest as JSON.
+func upsertPullRequest(input map[string]interface{}) string {
+	if debug == "true" {
+		jsonBody, _ := json.Marshal(input)
+		fmt.Println("Request input =", string(jsonBody))
+	}
+
+	query := `
+		mutation upsertPullRequestProgrammatic($input: PullRequestUpsertProgrammaticInput!) {
+			upsertPullRequestProgrammatic(input: $input) {
+				uuid
+				identity
+				state
+				title
+				targetVcsRepository
+				commits
+			}
+		}
+	`
+	variables := map[string]interface{}{"input": input}
+	return sendRequest(query, variables, "upsertPullRequestProgrammatic")
+}
+
 func init() {
 	pullRequestUpsertCmd.PersistentFlags().StringVar(&prUpsertIdentity, "identity", "", "SCM-side PR identity (string). GitHub PR number, GitLab MR iid, Gerrit change-id (required)")
 	pullRequestUpsertCmd.PersistentFlags().StringVar(&prUpsertState, "state", "", "PR state — OPEN | CLOSED | MERGED (required)")
@@ -149,6 +426,30 @@ func init() {
 	pullRequestUpsertCmd.MarkPersistentFlagRequired("identity")
 	pullRequestUpsertCmd.MarkPersistentFlagRequired("state")
 
+	pullRequestListCmd.PersistentFlags().StringVar(&prListComponent, "component", "", "UUID of the component whose branches to list pull requests of")
+	pullRequestListCmd.PersistentFlags().StringVar(&prListBranch, "branch", "", "(Optional) UUID of a single branch, instead of --component")
+	pullRequestListCmd.PersistentFlags().StringVar(&prListVcsUri, "vcsuri", "", "(Optional) URI of a VCS repository, lists pull requests of all its components instead of --component")
+	pullRequestListCmd.PersistentFlags().StringVar(&prListOrg, "org", "", "UUID of the organization, required with --vcsuri")
+	pullRequestListCmd.PersistentFlags().StringVar(&prListState, "state", "", "(Optional) Only list pull requests in this state — OPEN | CLOSED | MERGED")
+
+	pullRequestShowCmd.PersistentFlags().StringVar(&prListComponent, "component", "", "UUID of the component the pull request belongs to")
+	pullRequestShowCmd.PersistentFlags().StringVar(&prListBranch, "branch", "", "(Optional) UUID of the branch the pull request is recorded on, instead of --component")
+	pullRequestShowCmd.PersistentFlags().IntVar(&prShowNumber, "number", 0, "Number of the pull request (required unless --identity is set)")
+	pullRequestShowCmd.PersistentFlags().StringVar(&prShowIdentity, "identity", "", "SCM-side PR identity as given to upsert and close, must be numeric (required unless --number is set)")
+	pullRequestShowCmd.PersistentFlags().IntVar(&prShowNumRecords, "numrecords", 20, "(Optional) Maximum number of releases to return")
+
+	pullRequestCloseCmd.PersistentFlags().StringVar(&prIdentity, "identity", "", "SCM-side PR identity (string). GitHub PR number, GitLab MR iid, Gerrit change-id (required)")
+	pullRequestCloseCmd.PersistentFlags().BoolVar(&prCloseMerged, "merged", false, "(Optional) Mark the PR MERGED instead of CLOSED")
+	pullRequestCloseCmd.PersistentFlags().StringVar(&prTitle, "title", "", "(Optional) PR title")
+	pullRequestCloseCmd.PersistentFlags().StringVar(&prEndpoint, "endpoint", "", "(Optional) URL of the PR in the upstream SCM")
+	pullRequestCloseCmd.PersistentFlags().StringVar(&prUpsertComponent, "component", "", "(Optional) Component UUID — explicit target for ORG_RW/FREEFORM keys. Mutually exclusive with --vcsuri.")
+	pullRequestCloseCmd.PersistentFlags().StringVar(&prUpsertVcsUri, "vcsuri", "", "(Optional) VCS repository URI — for ORG_RW/FREEFORM keys when --component is not supplied")
+	pullRequestCloseCmd.PersistentFlags().StringVar(&prUpsertRepoPath, "repo-path", "", "(Optional) Repository path for monorepo components")
+	pullRequestCloseCmd.MarkPersistentFlagRequired("identity")
+
 	pullRequestCmd.AddCommand(pullRequestUpsertCmd)
+	pullRequestCmd.AddCommand(pullRequestListCmd)
+	pullRequestCmd.AddCommand(pullRequestShowCmd)
+	pullRequestCmd.AddCommand(pullRequestCloseCmd)
 	rootCmd.AddCommand(pullRequestCmd)
 }
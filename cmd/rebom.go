@@ -58,6 +58,7 @@ type Artifact struct {
 	File              []byte     `json:"file"`
 	FilePath          string     `json:"filePath,omitempty"`
 	StripBom          string     `json:"stripBom,omitempty"`
+	DigestRecords     []string   `json:"digestRecords,omitempty"`
 	// VEX-only fields, applied when type is "VEX" — they control how an
 	// inbound VEX document is imported. All optional; the backend applies
 	// defaults (scope COMPONENT, mode AUTO_ACCEPT) when omitted.
@@ -145,10 +146,10 @@ func attachToRebomFunc() {
 	fileInfo, err := os.Stat(infile)
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		exitCommand(1)
 	} else if fileInfo.IsDir() {
 		fmt.Println("Error: infile must be a path to a file, not a directory!")
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	if debug == "true" {
@@ -174,6 +175,14 @@ func attachToRebomFunc() {
 		SetFormData(body).
 		SetBasicAuth(apiKeyId, apiKey).
 		Post(rearmUri + "/api/programmatic/v1/sbom/upload")
+	if auditLogPath != "" {
+		data, auditErr := graphQLResponseData(resp, err)
+		vars := map[string]interface{}{"file": infile}
+		for k, v := range body {
+			vars[k] = v
+		}
+		recordAudit("sbomUpload", vars, data, auditErr)
+	}
 
 	handleResponse(err, resp)
 }
@@ -184,10 +193,10 @@ func ReadBomJsonFromFile(filePath string) map[string]interface{} {
 	fileInfo, err := os.Stat(filePath)
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		exitCommand(1)
 	} else if fileInfo.IsDir() {
 		fmt.Println("Error: infile must be a path to a file, not a directory!")
-		os.Exit(1)
+		exitCommand(1)
 	}
 	// Read infile if not directory:
 	fileContentByteSlice, _ := os.ReadFile(filePath)
@@ -199,7 +208,7 @@ func ReadBomJsonFromFile(filePath string) map[string]interface{} {
 	if parseError != nil {
 		fmt.Println("Error unmarshalling json bom file")
 		fmt.Println(parseError)
-		os.Exit(1)
+		exitCommand(1)
 	}
 	return bomJSON
 }
@@ -0,0 +1,242 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Commands operating on existing releases",
+	Long:  `Subcommand group for inspecting and acting on releases that already exist in ReARM, addressed by release UUID.`,
+}
+
+// RELEASE_CONTENT_GQL_DATA extends FULL_RELEASE_GQL_DATA with everything
+// needed to enumerate the artifacts attached to a release, its source code
+// entry and its deliverables, plus parent release details for products.
+const RELEASE_CONTENT_GQL_DATA = FULL_RELEASE_GQL_DATA + `
+	artifactDetails {
+		bomFormat
+		digestRecords {
+			algo
+			digest
+		}
+	}
+	sourceCodeEntryDetails {
+		artifactDetails {
+			uuid
+			displayIdentifier
+			type
+			bomFormat
+			digestRecords {
+				algo
+				digest
+			}
+		}
+	}
+	variantDetails {
+		outboundDeliverableDetails {
+			uuid
+			displayIdentifier
+			version
+			softwareMetadata {
+				digests
+			}
+			artifactDetails {
+				uuid
+				displayIdentifier
+				type
+				bomFormat
+				digestRecords {
+					algo
+					digest
+				}
+			}
+		}
+	}
+	parentReleases {
+		releaseDetails {
+			uuid
+			version
+			component
+			componentDetails {
+				uuid
+				name
+			}
+		}
+	}
+`
+
+// Artifact owners as reported by releaseContent.allArtifacts, matching the
+// belongsTo values used by the backend.
+const (
+	artifactOwnerRelease     = "RELEASE"
+	artifactOwnerSce         = "SCE"
+	artifactOwnerDeliverable = "DELIVERABLE"
+)
+
+// DigestRecord is a digest the backend computed for a stored artifact.
+type DigestRecord struct {
+	Algo   string `json:"algo"`
+	Digest string `json:"digest"`
+}
+
+type releaseArtifact struct {
+	Uuid              string         `json:"uuid"`
+	DisplayIdentifier string         `json:"displayIdentifier"`
+	Type              string         `json:"type"`
+	BomFormat         string         `json:"bomFormat,omitempty"`
+	Version           string         `json:"version,omitempty"`
+	Tags              []TagRecord    `json:"tags,omitempty"`
+	DigestRecords     []DigestRecord `json:"digestRecords,omitempty"`
+}
+
+type releaseDeliverable struct {
+	Uuid              string `json:"uuid"`
+	DisplayIdentifier string `json:"displayIdentifier"`
+	Version           string `json:"version"`
+	SoftwareMetadata  struct {
+		Digests []string `json:"digests"`
+	} `json:"softwareMetadata"`
+	ArtifactDetails []releaseArtifact `json:"artifactDetails"`
+}
+
+type releaseSummary struct {
+	Uuid             string `json:"uuid"`
+	Version          string `json:"version"`
+	Component        string `json:"component"`
+	ComponentDetails struct {
+		Uuid string `json:"uuid"`
+		Name string `json:"name"`
+	} `json:"componentDetails"`
+}
+
+// releaseContent is the typed form of a release fetched with
+// RELEASE_CONTENT_GQL_DATA.
+type releaseContent struct {
+	releaseSummary
+	Lifecycle      string `json:"lifecycle"`
+	Branch         string `json:"branch"`
+	ParentReleases []struct {
+		Release        string          `json:"release"`
+		ReleaseDetails *releaseSummary `json:"releaseDetails"`
+	} `json:"parentReleases"`
+	ArtifactDetails        []releaseArtifact `json:"artifactDetails"`
+	SourceCodeEntryDetails *struct {
+		Uuid            string            `json:"uuid"`
+		Commit          string            `json:"commit"`
+		ArtifactDetails []releaseArtifact `json:"artifactDetails"`
+	} `json:"sourceCodeEntryDetails"`
+	VariantDetails []struct {
+		OutboundDeliverableDetails []releaseDeliverable `json:"outboundDeliverableDetails"`
+	} `json:"variantDetails"`
+}
+
+// ownedArtifact is an artifact together with the entity it is attached to.
+type ownedArtifact struct {
+	releaseArtifact
+	BelongsTo   string `json:"belongsTo"`
+	Deliverable string `json:"deliverable,omitempty"`
+}
+
+// deliverables flattens the outbound deliverables across all variants,
+// skipping duplicates that appear in more than one variant.
+func (r *releaseContent) deliverables() []releaseDeliverable {
+	var out []releaseDeliverable
+	seen := make(map[string]bool)
+	for _, v := range r.VariantDetails {
+		for _, d := range v.OutboundDeliverableDetails {
+			if seen[d.Uuid] {
+				continue
+			}
+			seen[d.Uuid] = true
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// allArtifacts returns every artifact attached to the release, its source
+// code entry and its deliverables.
+func (r *releaseContent) allArtifacts() []ownedArtifact {
+	var out []ownedArtifact
+	for _, a := range r.ArtifactDetails {
+		out = append(out, ownedArtifact{releaseArtifact: a, BelongsTo: artifactOwnerRelease})
+	}
+	if r.SourceCodeEntryDetails != nil {
+		for _, a := range r.SourceCodeEntryDetails.ArtifactDetails {
+			out = append(out, ownedArtifact{releaseArtifact: a, BelongsTo: artifactOwnerSce})
+		}
+	}
+	for _, d := range r.deliverables() {
+		for _, a := range d.ArtifactDetails {
+			out = append(out, ownedArtifact{releaseArtifact: a, BelongsTo: artifactOwnerDeliverable, Deliverable: d.DisplayIdentifier})
+		}
+	}
+	return out
+}
+
+// fetchReleaseContent loads a release with RELEASE_CONTENT_GQL_DATA plus any
+// extra selection and decodes it into target, which should embed or be a
+// releaseContent.
+func fetchReleaseContent(releaseUuid string, extraSelection string, target interface{}) error {
+	rlz, err := fetchRelease(releaseUuid, RELEASE_CONTENT_GQL_DATA+extraSelection)
+	if err != nil {
+		return err
+	}
+	return decodeInto(rlz, target)
+}
+
+// fetchRelease loads a single release by UUID and returns only the requested
+// GraphQL selection. Returns an error when the release does not exist or is
+// not visible to the calling key.
+func fetchRelease(releaseUuid string, selection string) (map[string]interface{}, error) {
+	query := `
+		query ($releaseUuid: ID!) {
+			release(releaseUuid: $releaseUuid) {` + selection + `}
+		}
+	`
+	variables := map[string]interface{}{"releaseUuid": releaseUuid}
+	data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
+	if err != nil {
+		return nil, err
+	}
+	rlz, ok := data["release"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("release %s not found", releaseUuid)
+	}
+	return rlz, nil
+}
+
+// decodeInto round-trips a generic GraphQL response value through JSON so it
+// can be read into a typed struct.
+func decodeInto(value interface{}, target interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, target)
+}
+
+func init() {
+	rootCmd.AddCommand(releaseCmd)
+}
@@ -0,0 +1,331 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/package-url/packageurl-go"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffFormat   string
+	diffSkipBoms bool
+)
+
+const diffReleaseExtraSelection = `
+	metrics {
+		vulnerabilityDetails {
+			purl
+			vulnId
+			severity
+		}
+	}
+`
+
+type diffRelease struct {
+	releaseContent
+	Metrics *struct {
+		VulnerabilityDetails []Vulnerability `json:"vulnerabilityDetails"`
+	} `json:"metrics"`
+}
+
+// DiffEntry describes a single added, removed or changed item. From and To
+// carry the old and new value for changed items.
+type DiffEntry struct {
+	Key  string `json:"key"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+type DiffSection struct {
+	Added   []DiffEntry `json:"added"`
+	Removed []DiffEntry `json:"removed"`
+	Changed []DiffEntry `json:"changed"`
+}
+
+func (s DiffSection) empty() bool {
+	return len(s.Added) == 0 && len(s.Removed) == 0 && len(s.Changed) == 0
+}
+
+type ReleaseRef struct {
+	Uuid      string `json:"uuid"`
+	Component string `json:"component"`
+	Version   string `json:"version"`
+	Lifecycle string `json:"lifecycle"`
+}
+
+type ReleaseDiff struct {
+	From            ReleaseRef  `json:"from"`
+	To              ReleaseRef  `json:"to"`
+	Artifacts       DiffSection `json:"artifacts"`
+	Deliverables    DiffSection `json:"deliverables"`
+	Dependencies    DiffSection `json:"dependencies"`
+	Vulnerabilities DiffSection `json:"vulnerabilities"`
+	ParentReleases  DiffSection `json:"parentReleases"`
+	Warnings        []string    `json:"warnings,omitempty"`
+}
+
+var releaseDiffCmd = &cobra.Command{
+	Use:   "diff <from-release-uuid> <to-release-uuid>",
+	Short: "Show what changed between two releases",
+	Long: `Compares two releases and reports added, removed and changed artifacts,
+deliverable digests, dependency versions (taken from the CycloneDX BOM artifacts
+of each release), vulnerabilities and, for products, parent releases.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if diffFormat != "text" && diffFormat != "json" {
+			fmt.Fprintln(os.Stderr, "Error: --format must be text or json")
+			exitCommand(2)
+		}
+		var from, to diffRelease
+		if err := fetchReleaseContent(args[0], diffReleaseExtraSelection, &from); err != nil {
+			printGqlError(err)
+			exitCommand(1)
+		}
+		if err := fetchReleaseContent(args[1], diffReleaseExtraSelection, &to); err != nil {
+			printGqlError(err)
+			exitCommand(1)
+		}
+
+		diff := ReleaseDiff{
+			From:            releaseRef(&from),
+			To:              releaseRef(&to),
+			Artifacts:       diffMaps(artifactMap(&from), artifactMap(&to)),
+			Deliverables:    diffMaps(deliverableMap(&from), deliverableMap(&to)),
+			Vulnerabilities: diffMaps(vulnerabilityMap(&from), vulnerabilityMap(&to)),
+			ParentReleases:  diffMaps(parentReleaseMap(&from), parentReleaseMap(&to)),
+		}
+		if !diffSkipBoms {
+			fromDeps, fromWarn := dependencyMap(&from)
+			toDeps, toWarn := dependencyMap(&to)
+			diff.Dependencies = diffMaps(fromDeps, toDeps)
+			diff.Warnings = append(fromWarn, toWarn...)
+		}
+
+		if diffFormat == "json" {
+			emitJson(diff)
+			return
+		}
+		printReleaseDiff(&diff)
+	},
+}
+
+func releaseRef(r *diffRelease) ReleaseRef {
+	return ReleaseRef{
+		Uuid:      r.Uuid,
+		Component: r.ComponentDetails.Name,
+		Version:   r.Version,
+		Lifecycle: r.Lifecycle,
+	}
+}
+
+// artifactMap keys artifacts by owner, type and display identifier so the same
+// logical artifact re-uploaded in a later release is not reported as changed.
+func artifactMap(r *diffRelease) map[string]string {
+	m := make(map[string]string)
+	for _, a := range r.allArtifacts() {
+		owner := a.BelongsTo
+		if a.Deliverable != "" {
+			owner += "(" + a.Deliverable + ")"
+		}
+		m[owner+" "+a.Type+" "+a.DisplayIdentifier] = ""
+	}
+	return m
+}
+
+func deliverableMap(r *diffRelease) map[string]string {
+	m := make(map[string]string)
+	for _, d := range r.deliverables() {
+		digests := append([]string(nil), d.SoftwareMetadata.Digests...)
+		sort.Strings(digests)
+		m[d.DisplayIdentifier] = strings.Join(digests, ",")
+	}
+	return m
+}
+
+func vulnerabilityMap(r *diffRelease) map[string]string {
+	m := make(map[string]string)
+	if r.Metrics == nil {
+		return m
+	}
+	for _, v := range r.Metrics.VulnerabilityDetails {
+		m[v.VulnId+" "+v.Purl] = v.Severity
+	}
+	return m
+}
+
+// parentReleaseMap keys parent releases by component so a version bump of a
+// parent shows up as a change rather than an add/remove pair.
+func parentReleaseMap(r *diffRelease) map[string]string {
+	m := make(map[string]string)
+	for _, p := range r.ParentReleases {
+		if p.ReleaseDetails == nil {
+			m[p.Release] = p.Release
+			continue
+		}
+		key := p.ReleaseDetails.ComponentDetails.Name
+		if key == "" {
+			key = p.ReleaseDetails.Component
+		}
+		m[key] = p.ReleaseDetails.Version
+	}
+	return m
+}
+
+// dependencyMap downloads every CycloneDX BOM attached to the release and
+// returns component versions keyed by version-less purl (or group/name when
+// the component has no purl). BOMs that cannot be fetched or parsed are
+// reported as warnings rather than failing the diff.
+func dependencyMap(r *diffRelease) (map[string]string, []string) {
+	versions := make(map[string]map[string]bool)
+	var warnings []string
+	for _, a := range r.allArtifacts() {
+		if a.Type != "BOM" || (a.BomFormat != "" && a.BomFormat != "CYCLONEDX") {
+			continue
+		}
+		data, _, err := downloadArtifactBytes(a.Uuid, false, 0)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("release %s: could not download BOM %s: %v", r.Version, a.Uuid, err))
+			continue
+		}
+		bom := new(cdx.BOM)
+		if err := cdx.NewBOMDecoder(bytes.NewReader(data), cdx.BOMFileFormatJSON).Decode(bom); err != nil {
+			warnings = append(warnings, fmt.Sprintf("release %s: could not parse BOM %s: %v", r.Version, a.Uuid, err))
+			continue
+		}
+		if bom.Components == nil {
+			continue
+		}
+		for _, c := range *bom.Components {
+			key := dependencyKey(c)
+			if key == "" {
+				continue
+			}
+			if versions[key] == nil {
+				versions[key] = make(map[string]bool)
+			}
+			versions[key][c.Version] = true
+		}
+	}
+	// several versions of one dependency are joined in sorted order so the
+	// same set compares equal whatever order the BOMs list them in
+	m := make(map[string]string, len(versions))
+	for key, set := range versions {
+		list := make([]string, 0, len(set))
+		for v := range set {
+			list = append(list, v)
+		}
+		sort.Strings(list)
+		m[key] = strings.Join(list, ",")
+	}
+	return m, warnings
+}
+
+func dependencyKey(c cdx.Component) string {
+	if c.PackageURL != "" {
+		if p, err := packageurl.FromString(c.PackageURL); err == nil {
+			p.Version = ""
+			p.Qualifiers = nil
+			p.Subpath = ""
+			return p.ToString()
+		}
+	}
+	if c.Name == "" {
+		return ""
+	}
+	if c.Group != "" {
+		return c.Group + "/" + c.Name
+	}
+	return c.Name
+}
+
+// diffMaps compares two key/value maps; keys only in to are added, keys only
+// in from are removed and keys present in both with different values are
+// changed. Results are sorted by key.
+func diffMaps(from, to map[string]string) DiffSection {
+	section := DiffSection{Added: []DiffEntry{}, Removed: []DiffEntry{}, Changed: []DiffEntry{}}
+	for k, v := range to {
+		old, ok := from[k]
+		if !ok {
+			section.Added = append(section.Added, DiffEntry{Key: k, To: v})
+		} else if old != v {
+			section.Changed = append(section.Changed, DiffEntry{Key: k, From: old, To: v})
+		}
+	}
+	for k, v := range from {
+		if _, ok := to[k]; !ok {
+			section.Removed = append(section.Removed, DiffEntry{Key: k, From: v})
+		}
+	}
+	for _, list := range [][]DiffEntry{section.Added, section.Removed, section.Changed} {
+		sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	}
+	return section
+}
+
+func printReleaseDiff(diff *ReleaseDiff) {
+	fmt.Printf("Release diff: %s %s (%s) -> %s %s (%s)\n",
+		diff.From.Component, diff.From.Version, diff.From.Uuid,
+		diff.To.Component, diff.To.Version, diff.To.Uuid)
+	sections := []struct {
+		title   string
+		section DiffSection
+		skip    bool
+	}{
+		{"Artifacts", diff.Artifacts, false},
+		{"Deliverables", diff.Deliverables, false},
+		{"Dependencies", diff.Dependencies, diffSkipBoms},
+		{"Vulnerabilities", diff.Vulnerabilities, false},
+		{"Parent releases", diff.ParentReleases, false},
+	}
+	for _, s := range sections {
+		if s.skip {
+			continue
+		}
+		fmt.Printf("\n%s:\n", s.title)
+		if s.section.empty() {
+			fmt.Println("  (no changes)")
+			continue
+		}
+		for _, e := range s.section.Added {
+			fmt.Println("  +", strings.TrimSpace(e.Key+" "+e.To))
+		}
+		for _, e := range s.section.Removed {
+			fmt.Println("  -", strings.TrimSpace(e.Key+" "+e.From))
+		}
+		for _, e := range s.section.Changed {
+			fmt.Printf("  ~ %s: %s -> %s\n", e.Key, e.From, e.To)
+		}
+	}
+	for _, w := range diff.Warnings {
+		fmt.Fprintln(os.Stderr, "Warning:", w)
+	}
+}
+
+func init() {
+	releaseDiffCmd.PersistentFlags().StringVar(&diffFormat, "format", "text", "Output format: text or json")
+	releaseDiffCmd.PersistentFlags().BoolVar(&diffSkipBoms, "skip-boms", false, "Do not download BOM artifacts, skipping the dependency comparison")
+	releaseCmd.AddCommand(releaseDiffCmd)
+}
@@ -0,0 +1,229 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	rlzDownloadRelease     string
+	rlzDownloadOut         string
+	rlzDownloadConcurrency int
+	rlzDownloadProcessed   bool
+)
+
+// Verification results recorded in the download index.
+const (
+	downloadVerified = "VERIFIED"
+	downloadMismatch = "MISMATCH"
+	downloadNoDigest = "NO_DIGEST"
+	downloadFailed   = "FAILED"
+)
+
+// DownloadedArtifact is one entry of the index written by release download.
+type DownloadedArtifact struct {
+	Uuid              string `json:"uuid"`
+	DisplayIdentifier string `json:"displayIdentifier,omitempty"`
+	Type              string `json:"type,omitempty"`
+	BelongsTo         string `json:"belongsTo"`
+	Deliverable       string `json:"deliverable,omitempty"`
+	Path              string `json:"path,omitempty"`
+	Size              int64  `json:"size"`
+	Sha256            string `json:"sha256,omitempty"`
+	Sha512            string `json:"sha512,omitempty"`
+	Verification      string `json:"verification"`
+	Error             string `json:"error,omitempty"`
+}
+
+// ReleaseDownloadIndex is written as index.json into the output directory.
+type ReleaseDownloadIndex struct {
+	Release    ReleaseRef           `json:"release"`
+	Downloaded string               `json:"downloaded"`
+	Artifacts  []DownloadedArtifact `json:"artifacts"`
+}
+
+var releaseDownloadCmd = &cobra.Command{
+	Use:   "download",
+	Short: "Download all artifacts of a release into a directory",
+	Long: `Downloads every artifact attached to a release, its source code entry and its
+deliverables into a directory structured as
+
+  <out>/release/<artifact uuid>/<file>
+  <out>/sce/<artifact uuid>/<file>
+  <out>/deliverables/<deliverable>/<artifact uuid>/<file>
+
+Files are streamed to disk and checked against the sha256 / sha512 digests recorded
+on the artifact. An index.json describing what was fetched is written to <out>.
+Exits with code 1 if any download failed or any digest did not match.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if rlzDownloadConcurrency < 1 {
+			fmt.Fprintln(os.Stderr, "Error: --concurrency must be at least 1")
+			exitCommand(2)
+		}
+		if debug == "true" {
+			fmt.Fprintln(os.Stderr, "Using ReARM at", rearmUri)
+		}
+		var rlz releaseContent
+		if err := fetchReleaseContent(rlzDownloadRelease, "", &rlz); err != nil {
+			printGqlError(err)
+			exitCommand(1)
+		}
+		arts := rlz.allArtifacts()
+		fmt.Fprintf(os.Stderr, "Downloading %d artifact(s) of %s %s\n", len(arts), rlz.ComponentDetails.Name, rlz.Version)
+
+		index := ReleaseDownloadIndex{
+			Release: ReleaseRef{
+				Uuid:      rlz.Uuid,
+				Version:   rlz.Version,
+				Component: rlz.ComponentDetails.Name,
+				Lifecycle: rlz.Lifecycle,
+			},
+			Downloaded: time.Now().UTC().Format(time.RFC3339),
+			Artifacts:  downloadReleaseArtifacts(arts, rlzDownloadOut, !rlzDownloadProcessed, rlzDownloadConcurrency),
+		}
+
+		b, _ := json.MarshalIndent(index, "", "  ")
+		indexPath := filepath.Join(rlzDownloadOut, "index.json")
+		if err := os.WriteFile(indexPath, b, 0644); err != nil {
+			fmt.Fprintln(os.Stderr, "Error writing index:", err)
+			exitCommand(1)
+		}
+
+		failed := 0
+		for _, a := range index.Artifacts {
+			if a.Verification == downloadMismatch || a.Verification == downloadFailed {
+				failed++
+			}
+		}
+		fmt.Println(indexPath)
+		if failed > 0 {
+			fmt.Fprintf(os.Stderr, "%d of %d artifact(s) failed download or verification\n", failed, len(index.Artifacts))
+			exitCommand(1)
+		}
+	},
+}
+
+// downloadReleaseArtifacts fetches arts into out with at most concurrency
+// downloads in flight. Results are returned in input order.
+func downloadReleaseArtifacts(arts []ownedArtifact, out string, raw bool, concurrency int) []DownloadedArtifact {
+	results := make([]DownloadedArtifact, len(arts))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for i, a := range arts {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, a ownedArtifact) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = downloadReleaseArtifact(a, out, raw)
+			mu.Lock()
+			defer mu.Unlock()
+			r := results[i]
+			if r.Error != "" {
+				fmt.Fprintf(os.Stderr, "  %s %s: %s\n", r.Verification, a.Uuid, r.Error)
+			} else {
+				fmt.Fprintf(os.Stderr, "  %s %s\n", r.Verification, r.Path)
+			}
+		}(i, a)
+	}
+	wg.Wait()
+	return results
+}
+
+func downloadReleaseArtifact(a ownedArtifact, out string, raw bool) DownloadedArtifact {
+	result := DownloadedArtifact{
+		Uuid:              a.Uuid,
+		DisplayIdentifier: a.DisplayIdentifier,
+		Type:              a.Type,
+		BelongsTo:         a.BelongsTo,
+		Deliverable:       a.Deliverable,
+	}
+	var dir string
+	switch a.BelongsTo {
+	case artifactOwnerSce:
+		dir = filepath.Join(out, "sce", a.Uuid)
+	case artifactOwnerDeliverable:
+		dir = filepath.Join(out, "deliverables", sanitizeFilename(a.Deliverable), a.Uuid)
+	default:
+		dir = filepath.Join(out, "release", a.Uuid)
+	}
+	fallback := a.DisplayIdentifier
+	if fallback == "" {
+		fallback = a.Uuid
+	}
+	dl, err := streamArtifactToDir(a.Uuid, raw, 0, dir, "", fallback, "", nil)
+	if err != nil {
+		result.Verification = downloadFailed
+		result.Error = err.Error()
+		return result
+	}
+	if rel, err := filepath.Rel(out, dl.Path); err == nil {
+		result.Path = filepath.ToSlash(rel)
+	} else {
+		result.Path = dl.Path
+	}
+	result.Size = dl.Size
+	result.Sha256 = dl.Sha256
+	result.Sha512 = dl.Sha512
+	result.Verification, result.Error = verifyDigestRecords(a.DigestRecords, dl.Sha256, dl.Sha512)
+	return result
+}
+
+// verifyDigestRecords compares computed digests against the sha256 / sha512
+// records of an artifact. Records using other algorithms are ignored.
+func verifyDigestRecords(records []DigestRecord, sha256Hex string, sha512Hex string) (string, string) {
+	checked := false
+	for _, dr := range records {
+		if d := normalizeSha256(dr.Algo, dr.Digest); d != "" {
+			checked = true
+			if d != sha256Hex {
+				return downloadMismatch, fmt.Sprintf("sha256 mismatch: recorded %s, got %s", d, sha256Hex)
+			}
+		}
+		if d := normalizeSha512(dr.Algo, dr.Digest); d != "" {
+			checked = true
+			if d != sha512Hex {
+				return downloadMismatch, fmt.Sprintf("sha512 mismatch: recorded %s, got %s", d, sha512Hex)
+			}
+		}
+	}
+	if !checked {
+		return downloadNoDigest, ""
+	}
+	return downloadVerified, ""
+}
+
+func init() {
+	releaseDownloadCmd.PersistentFlags().StringVar(&rlzDownloadRelease, "release", "", "UUID of the release")
+	releaseDownloadCmd.PersistentFlags().StringVar(&rlzDownloadOut, "out", "", "Output directory")
+	releaseDownloadCmd.PersistentFlags().IntVar(&rlzDownloadConcurrency, "concurrency", 4, "Number of parallel downloads")
+	releaseDownloadCmd.PersistentFlags().BoolVar(&rlzDownloadProcessed, "processed", false, "(Optional) Download processed artifacts instead of the raw uploaded files; digests may not match")
+	releaseDownloadCmd.MarkPersistentFlagRequired("release")
+	releaseDownloadCmd.MarkPersistentFlagRequired("out")
+
+	releaseCmd.AddCommand(releaseDownloadCmd)
+}
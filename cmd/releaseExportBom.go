@@ -0,0 +1,126 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportRelease   string
+	exportStructure string
+	exportTldOnly   bool
+	exportBelongsTo string
+	exportType      string
+	exportOutfile   string
+)
+
+var releaseExportBomCmd = &cobra.Command{
+	Use:   "export-bom",
+	Short: "Export the aggregated SBOM or OBOM of a release",
+	Long: `Exports the aggregated CycloneDX SBOM of a release (releaseSbomExport) or its
+OBOM (exportAsObomManual) and writes it to a file or stdout.
+
+--structure, --tld-only and --belongs-to only apply to SBOM exports.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Fprintln(os.Stderr, "Using ReARM at", rearmUri)
+		}
+		bom, err := exportReleaseBom(exportRelease, strings.ToLower(exportType), strings.ToUpper(exportStructure), exportTldOnly, strings.ToUpper(exportBelongsTo))
+		if err != nil {
+			printGqlError(err)
+			exitCommand(1)
+		}
+		if exportOutfile == "" || exportOutfile == "-" {
+			fmt.Println(bom)
+			return
+		}
+		if err := os.WriteFile(exportOutfile, []byte(bom), 0644); err != nil {
+			fmt.Fprintln(os.Stderr, "Error writing file:", err)
+			exitCommand(1)
+		}
+		fmt.Println(exportOutfile)
+	},
+}
+
+// exportReleaseBom returns the aggregated BOM of a release as produced by the
+// backend. bomType is sbom or obom; structure and belongsTo may be empty to
+// use the backend defaults.
+func exportReleaseBom(releaseUuid, bomType, structure string, tldOnly bool, belongsTo string) (string, error) {
+	var query, opName string
+	variables := map[string]interface{}{}
+	switch bomType {
+	case "sbom":
+		if structure != "" && structure != "FLAT" && structure != "HIERARCHICAL" {
+			return "", fmt.Errorf("--structure must be FLAT or HIERARCHICAL")
+		}
+		if belongsTo != "" && belongsTo != artifactOwnerDeliverable && belongsTo != artifactOwnerRelease && belongsTo != artifactOwnerSce {
+			return "", fmt.Errorf("--belongs-to must be DELIVERABLE, RELEASE or SCE")
+		}
+		query = `
+			mutation ($release: ID!, $tldOnly: Boolean, $structure: BomStructureType, $belongsTo: ArtifactBelongsToEnum) {
+				releaseSbomExport(release: $release, tldOnly: $tldOnly, structure: $structure, belongsTo: $belongsTo)
+			}
+		`
+		opName = "releaseSbomExport"
+		variables["release"] = releaseUuid
+		variables["tldOnly"] = tldOnly
+		if structure != "" {
+			variables["structure"] = structure
+		}
+		if belongsTo != "" {
+			variables["belongsTo"] = belongsTo
+		}
+	case "obom":
+		query = `
+			query ($releaseUuid: ID!) {
+				exportAsObomManual(releaseUuid: $releaseUuid)
+			}
+		`
+		opName = "exportAsObomManual"
+		variables["releaseUuid"] = releaseUuid
+	default:
+		return "", fmt.Errorf("--type must be sbom or obom")
+	}
+
+	data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
+	if err != nil {
+		return "", err
+	}
+	bom, ok := data[opName].(string)
+	if !ok || bom == "" {
+		return "", fmt.Errorf("no %s returned for release %s", strings.ToUpper(bomType), releaseUuid)
+	}
+	return bom, nil
+}
+
+func init() {
+	releaseExportBomCmd.PersistentFlags().StringVar(&exportRelease, "release", "", "UUID of the release")
+	releaseExportBomCmd.PersistentFlags().StringVar(&exportType, "type", "sbom", "BOM to export: sbom or obom")
+	releaseExportBomCmd.PersistentFlags().StringVar(&exportStructure, "structure", "", "(Optional, sbom only) FLAT or HIERARCHICAL")
+	releaseExportBomCmd.PersistentFlags().BoolVar(&exportTldOnly, "tld-only", false, "(Optional, sbom only) Include only top level dependencies")
+	releaseExportBomCmd.PersistentFlags().StringVar(&exportBelongsTo, "belongs-to", "", "(Optional, sbom only) Only aggregate BOMs attached to DELIVERABLE, RELEASE or SCE")
+	releaseExportBomCmd.PersistentFlags().StringVarP(&exportOutfile, "outfile", "o", "", "Output file path (default: stdout)")
+	releaseExportBomCmd.MarkPersistentFlagRequired("release")
+
+	releaseCmd.AddCommand(releaseExportBomCmd)
+}
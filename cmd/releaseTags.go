@@ -0,0 +1,260 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	tagsOrg     string
+	tagsBranch  string
+	tagsRelease string
+	tagsTag     string
+	tagsKeys    []string
+)
+
+var releaseFindCmd = &cobra.Command{
+	Use:   "find",
+	Short: "Find releases carrying a tag",
+	Long: `Lists the releases of an organization, optionally limited to one branch, that carry
+the tag given with --tag key=value. With --tag key any value of the key matches.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM at", rearmUri)
+		}
+		key, value, hasValue := strings.Cut(tagsTag, "=")
+		if key == "" {
+			fmt.Fprintln(os.Stderr, "Error: --tag must be in key=value or key form")
+			exitCommand(2)
+		}
+		keys, err := releaseTagKeys(tagsOrg)
+		if err != nil {
+			printGqlError(err)
+			exitCommand(1)
+		}
+		if keys != nil && !contains(keys, key) {
+			fmt.Fprintf(os.Stderr, "Error: no release in the organization is tagged with key %q, known keys: %s\n", key, strings.Join(keys, ", "))
+			exitCommand(1)
+		}
+		query := `
+			query ($orgUuid: ID!, $branchUuid: ID, $tagKey: String!, $tagValue: String) {
+				releasesByTags(orgUuid: $orgUuid, branchUuid: $branchUuid, tagKey: $tagKey, tagValue: $tagValue) {` + RELEASE_GQL_DATA + RELEASE_TAGS_GQL_DATA + `}
+			}
+		`
+		variables := map[string]interface{}{"orgUuid": tagsOrg, "tagKey": key}
+		if hasValue {
+			variables["tagValue"] = value
+		}
+		if tagsBranch != "" {
+			variables["branchUuid"] = tagsBranch
+		}
+		fmt.Println(sendRequest(query, variables, "releasesByTags"))
+	},
+}
+
+var releaseTagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Manage tags of an existing release",
+}
+
+var releaseTagKeysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "List the tag keys used on releases of an organization",
+	Run: func(cmd *cobra.Command, args []string) {
+		keys, err := releaseTagKeys(tagsOrg)
+		if err != nil {
+			printGqlError(err)
+			exitCommand(1)
+		}
+		if keys == nil {
+			keys = []string{}
+		}
+		emitJson(keys)
+	},
+}
+
+var releaseTagAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add or overwrite tags on a release",
+	Long: `Adds the tags given with --tag key=value to a release. A tag with the same key is
+replaced with the new value.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// later --tag flags win over earlier ones with the same key
+		add := map[string]string{}
+		var order []string
+		for _, t := range tagsKeys {
+			key, value, ok := strings.Cut(t, "=")
+			if !ok || key == "" {
+				fmt.Fprintf(os.Stderr, "Error: --tag must be in key=value form, got %q\n", t)
+				exitCommand(2)
+			}
+			if _, seen := add[key]; !seen {
+				order = append(order, key)
+			}
+			add[key] = value
+		}
+		org, tags := currentReleaseTags(tagsRelease)
+		var updated []TagInput
+		for _, t := range tags {
+			if _, replaced := add[t.Key]; !replaced {
+				updated = append(updated, TagInput{Key: t.Key, Value: t.Value})
+			}
+		}
+		for _, key := range order {
+			updated = append(updated, TagInput{Key: key, Value: add[key]})
+		}
+		fmt.Println(updateReleaseTags(tagsRelease, org, updated))
+	},
+}
+
+var releaseTagRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove tags from a release",
+	Long: `Removes the tags with the keys given with --tag from a release. --tag key=value only
+removes the tag when it has that value. Tags marked as not removable are refused.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		org, tags := currentReleaseTags(tagsRelease)
+		var updated []TagInput
+		removed := 0
+		for _, t := range tags {
+			if !matchesAnyTag(t, tagsKeys) {
+				updated = append(updated, TagInput{Key: t.Key, Value: t.Value})
+				continue
+			}
+			if t.Removable == "NO" {
+				fmt.Fprintf(os.Stderr, "Error: tag %s on release %s is not removable\n", t.Key, tagsRelease)
+				exitCommand(1)
+			}
+			removed++
+		}
+		if removed == 0 {
+			fmt.Fprintln(os.Stderr, "Error: none of the given tags is set on release", tagsRelease)
+			exitCommand(1)
+		}
+		if updated == nil {
+			updated = []TagInput{}
+		}
+		fmt.Println(updateReleaseTags(tagsRelease, org, updated))
+	},
+}
+
+const RELEASE_TAGS_GQL_DATA = `
+	tags {
+		key
+		value
+		removable
+	}
+`
+
+type releaseTag struct {
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+	Removable string `json:"removable"`
+}
+
+// releaseTagKeys returns the sorted tag keys in use in the organization, or
+// nil when the backend returns none.
+func releaseTagKeys(org string) ([]string, error) {
+	data, err := sendGraphQLRequest(`
+		query ($orgUuid: ID!) {
+			releaseTagKeys(orgUuid: $orgUuid)
+		}
+	`, map[string]interface{}{"orgUuid": org}, rearmUri+"/graphql")
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	if err := decodeInto(data["releaseTagKeys"], &keys); err != nil {
+		return nil, err
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// currentReleaseTags loads the organization and tags of a release, exiting on
+// errors.
+func currentReleaseTags(releaseUuid string) (string, []releaseTag) {
+	rlz, err := fetchRelease(releaseUuid, "uuid org"+RELEASE_TAGS_GQL_DATA)
+	if err != nil {
+		printGqlError(err)
+		exitCommand(1)
+	}
+	var current struct {
+		Org  string       `json:"org"`
+		Tags []releaseTag `json:"tags"`
+	}
+	if err := decodeInto(rlz, &current); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		exitCommand(1)
+	}
+	return current.Org, current.Tags
+}
+
+// updateReleaseTags replaces the tags of a release through updateRelease.
+func updateReleaseTags(releaseUuid string, org string, tags []TagInput) string {
+	query := `
+		mutation ($release: ReleaseInput!) {
+			updateRelease(release: $release) {` + RELEASE_GQL_DATA + RELEASE_TAGS_GQL_DATA + `}
+		}
+	`
+	variables := map[string]interface{}{"release": map[string]interface{}{"uuid": releaseUuid, "org": org, "tags": tags}}
+	return sendRequest(query, variables, "updateRelease")
+}
+
+func matchesAnyTag(t releaseTag, filters []string) bool {
+	for _, f := range filters {
+		key, value, hasValue := strings.Cut(f, "=")
+		if t.Key == key && (!hasValue || t.Value == value) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	releaseFindCmd.PersistentFlags().StringVar(&tagsOrg, "org", "", "UUID of the organization")
+	releaseFindCmd.PersistentFlags().StringVar(&tagsTag, "tag", "", "Tag to look for, key=value or key")
+	releaseFindCmd.PersistentFlags().StringVar(&tagsBranch, "branch", "", "(Optional) UUID of the branch to limit the search to")
+	releaseFindCmd.MarkPersistentFlagRequired("org")
+	releaseFindCmd.MarkPersistentFlagRequired("tag")
+
+	releaseTagKeysCmd.PersistentFlags().StringVar(&tagsOrg, "org", "", "UUID of the organization")
+	releaseTagKeysCmd.MarkPersistentFlagRequired("org")
+
+	releaseTagAddCmd.PersistentFlags().StringVar(&tagsRelease, "release", "", "UUID of the release")
+	releaseTagAddCmd.PersistentFlags().StringArrayVar(&tagsKeys, "tag", []string{}, "Tag to add in key=value form, repeatable")
+	releaseTagAddCmd.MarkPersistentFlagRequired("release")
+	releaseTagAddCmd.MarkPersistentFlagRequired("tag")
+
+	releaseTagRemoveCmd.PersistentFlags().StringVar(&tagsRelease, "release", "", "UUID of the release")
+	releaseTagRemoveCmd.PersistentFlags().StringArrayVar(&tagsKeys, "tag", []string{}, "Key, or key=value, of the tag to remove, repeatable")
+	releaseTagRemoveCmd.MarkPersistentFlagRequired("release")
+	releaseTagRemoveCmd.MarkPersistentFlagRequired("tag")
+
+	releaseTagCmd.AddCommand(releaseTagKeysCmd)
+	releaseTagCmd.AddCommand(releaseTagAddCmd)
+	releaseTagCmd.AddCommand(releaseTagRemoveCmd)
+	releaseCmd.AddCommand(releaseFindCmd)
+	releaseCmd.AddCommand(releaseTagCmd)
+}
@@ -0,0 +1,338 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Exit codes of `release wait`. Errors (bad flags, failed requests) keep
+// the usual exit code 1 so pipelines can tell them apart from a verdict.
+const (
+	waitExitSatisfied = 0
+	waitExitRejected  = 3
+	waitExitTimeout   = 4
+)
+
+const (
+	waitResultSatisfied = "SATISFIED"
+	waitResultRejected  = "REJECTED"
+	waitResultTimeout   = "TIMEOUT"
+	waitResultPending   = "PENDING"
+)
+
+var (
+	waitReleaseUuid string
+	waitUntil       []string
+	waitTimeout     time.Duration
+	waitInterval    time.Duration
+)
+
+// releaseLifecycleOrder is the forward progression of a release. REJECTED and
+// CANCELLED are terminal and sit outside of it.
+var releaseLifecycleOrder = []string{"PENDING", "DRAFT", "ASSEMBLED", "GENERAL_AVAILABILITY", "END_OF_SUPPORT"}
+
+var releaseLifecycleAliases = map[string]string{
+	"GA":  "GENERAL_AVAILABILITY",
+	"EOS": "END_OF_SUPPORT",
+}
+
+const waitReleaseGqlData = `
+	uuid
+	version
+	lifecycle
+	approvalEvents {
+		approvalEntry
+		approvalRoleId
+		state
+		date
+	}
+`
+
+type WaitApprovalEvent struct {
+	ApprovalEntry  string `json:"approvalEntry"`
+	ApprovalRoleId string `json:"approvalRoleId"`
+	State          string `json:"state"`
+	Date           string `json:"date"`
+}
+
+type WaitReleaseState struct {
+	Uuid           string              `json:"uuid"`
+	Version        string              `json:"version"`
+	Lifecycle      string              `json:"lifecycle"`
+	ApprovalEvents []WaitApprovalEvent `json:"approvalEvents"`
+}
+
+// WaitCondition is a single lifecycle=<value> or approval=<entry>:<state> gate.
+type WaitCondition struct {
+	Kind  string // "lifecycle" or "approval"
+	Entry string
+	Value string
+}
+
+func (c WaitCondition) String() string {
+	if c.Kind == "approval" {
+		return "approval=" + c.Entry + ":" + c.Value
+	}
+	return "lifecycle=" + c.Value
+}
+
+type waitVerdict struct {
+	Release   string `json:"release"`
+	Version   string `json:"version"`
+	Lifecycle string `json:"lifecycle"`
+	Result    string `json:"result"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+var releaseWaitCmd = &cobra.Command{
+	Use:   "wait",
+	Short: "Block until a release reaches a lifecycle or approval gate",
+	Long: `Polls a release until the --until gate is satisfied, refused, or --timeout
+elapses. Intended for CD pipelines that must not deploy before a release is
+approved or promoted.
+
+Each --until value is one of:
+  lifecycle=<LIFECYCLE>           satisfied once the release reached this
+                                  lifecycle or a later one (GA and EOS are
+                                  accepted as shorthands)
+  approval=<entry-uuid>:<STATE>   satisfied once the latest approval event
+                                  for the entry has the given state
+
+Alternatives within one --until value are separated by '|' and any of them
+satisfies it; repeated --until flags must all be satisfied.
+
+A gate is refused when the release moves to REJECTED or CANCELLED, or when
+an awaited approval is DISAPPROVED.
+
+Progress is reported on stderr; the final verdict is printed as JSON on
+stdout. Exit codes: 0 satisfied, 3 rejected or disapproved, 4 timed out,
+1 on any other error.
+
+Example:
+  rearm release wait -i $APIKEY_ID -k $APIKEY_SECRET -u $REARM_URI \
+    --release <release-uuid> \
+    --until 'lifecycle=GA|approval=<entry-uuid>:APPROVED' --timeout 2h`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Fprintln(os.Stderr, "Using ReARM at", rearmUri)
+		}
+		gates := make([][]WaitCondition, 0, len(waitUntil))
+		for _, u := range waitUntil {
+			gate, err := parseWaitGate(u)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err)
+				exitCommand(1)
+			}
+			gates = append(gates, gate)
+		}
+		exitCommand(releaseWaitFunc(gates))
+	},
+}
+
+// parseWaitGate parses one --until value into its '|'-separated alternatives.
+func parseWaitGate(raw string) ([]WaitCondition, error) {
+	var gate []WaitCondition
+	for _, alt := range strings.Split(raw, "|") {
+		alt = strings.TrimSpace(alt)
+		key, value, found := strings.Cut(alt, "=")
+		if !found || value == "" {
+			return nil, fmt.Errorf("invalid --until condition %q, expected lifecycle=<LIFECYCLE> or approval=<entry>:<STATE>", alt)
+		}
+		switch strings.ToLower(key) {
+		case "lifecycle":
+			lc := strings.ToUpper(value)
+			if full, ok := releaseLifecycleAliases[lc]; ok {
+				lc = full
+			}
+			if lifecycleIndex(lc) < 0 && lc != "REJECTED" && lc != "CANCELLED" {
+				return nil, fmt.Errorf("unknown lifecycle %q in --until", value)
+			}
+			gate = append(gate, WaitCondition{Kind: "lifecycle", Value: lc})
+		case "approval":
+			entry, state, found := strings.Cut(value, ":")
+			if !found || entry == "" {
+				return nil, fmt.Errorf("invalid approval condition %q, expected approval=<entry>:<STATE>", alt)
+			}
+			state = strings.ToUpper(state)
+			if state != "APPROVED" && state != "DISAPPROVED" {
+				return nil, fmt.Errorf("approval state must be APPROVED or DISAPPROVED, got %q", state)
+			}
+			gate = append(gate, WaitCondition{Kind: "approval", Entry: entry, Value: state})
+		default:
+			return nil, fmt.Errorf("unknown --until condition type %q", key)
+		}
+	}
+	return gate, nil
+}
+
+func lifecycleIndex(lifecycle string) int {
+	for i, lc := range releaseLifecycleOrder {
+		if lc == lifecycle {
+			return i
+		}
+	}
+	return -1
+}
+
+// EvaluateWaitCondition returns SATISFIED, REJECTED or PENDING for a single
+// condition against the current release state. An approval entry decided
+// against the awaited state rejects the condition either way round.
+func EvaluateWaitCondition(c WaitCondition, state WaitReleaseState) string {
+	if c.Kind == "lifecycle" {
+		if state.Lifecycle == c.Value {
+			return waitResultSatisfied
+		}
+		if state.Lifecycle == "REJECTED" || state.Lifecycle == "CANCELLED" {
+			return waitResultRejected
+		}
+		current, target := lifecycleIndex(state.Lifecycle), lifecycleIndex(c.Value)
+		if current >= 0 && target >= 0 && current >= target {
+			return waitResultSatisfied
+		}
+		return waitResultPending
+	}
+	// Approval events are returned in chronological order; the last one
+	// for the entry is its current state.
+	latest := ""
+	for _, ev := range state.ApprovalEvents {
+		if ev.ApprovalEntry == c.Entry {
+			latest = ev.State
+		}
+	}
+	if latest == c.Value {
+		return waitResultSatisfied
+	}
+	if latest == "APPROVED" || latest == "DISAPPROVED" {
+		// the entry was decided the other way
+		return waitResultRejected
+	}
+	if state.Lifecycle == "REJECTED" || state.Lifecycle == "CANCELLED" {
+		return waitResultRejected
+	}
+	return waitResultPending
+}
+
+// evaluateWaitGates combines the gates: every gate must be satisfied by at
+// least one of its alternatives; a gate whose alternatives are all refused
+// refuses the whole wait.
+func evaluateWaitGates(gates [][]WaitCondition, state WaitReleaseState) (string, string) {
+	result := waitResultSatisfied
+	for _, gate := range gates {
+		gateResult := waitResultRejected
+		var refused []string
+		for _, c := range gate {
+			r := EvaluateWaitCondition(c, state)
+			if r == waitResultSatisfied {
+				gateResult = waitResultSatisfied
+				break
+			}
+			if r == waitResultPending {
+				gateResult = waitResultPending
+			} else {
+				refused = append(refused, c.String())
+			}
+		}
+		if gateResult == waitResultRejected {
+			return waitResultRejected, "refused: " + strings.Join(refused, ", ")
+		}
+		if gateResult == waitResultPending {
+			result = waitResultPending
+		}
+	}
+	return result, ""
+}
+
+func fetchWaitReleaseState() (WaitReleaseState, error) {
+	var state WaitReleaseState
+	rlz, err := fetchRelease(waitReleaseUuid, waitReleaseGqlData)
+	if err != nil {
+		return state, err
+	}
+	err = decodeInto(rlz, &state)
+	return state, err
+}
+
+func releaseWaitFunc(gates [][]WaitCondition) int {
+	state, err := fetchWaitReleaseState()
+	if err != nil {
+		printGqlError(err)
+		return 1
+	}
+	result, reason := evaluateWaitGates(gates, state)
+
+	if result == waitResultPending {
+		fmt.Fprintf(os.Stderr, "Waiting for release %s (%s), lifecycle %s\n", state.Version, state.Uuid, state.Lifecycle)
+		spinner := startProgressSpinner(os.Stderr, "Lifecycle: "+state.Lifecycle)
+		err = pollUntil(waitInterval, waitTimeout, func() (bool, error) {
+			newState, err := fetchWaitReleaseState()
+			if err != nil {
+				return false, err
+			}
+			if newState.Lifecycle != state.Lifecycle || len(newState.ApprovalEvents) != len(state.ApprovalEvents) {
+				spinner.SetMessage("Lifecycle: " + newState.Lifecycle + ", approval events: " + fmt.Sprint(len(newState.ApprovalEvents)))
+			}
+			state = newState
+			result, reason = evaluateWaitGates(gates, state)
+			return result != waitResultPending, nil
+		})
+		spinner.Stop()
+		if err == errPollTimeout {
+			result = waitResultTimeout
+			reason = "timed out after " + waitTimeout.String()
+		} else if err != nil {
+			printGqlError(err)
+			return 1
+		}
+	}
+
+	verdict := waitVerdict{
+		Release:   state.Uuid,
+		Version:   state.Version,
+		Lifecycle: state.Lifecycle,
+		Result:    result,
+		Reason:    reason,
+	}
+	out, _ := json.Marshal(verdict)
+	fmt.Println(string(out))
+
+	switch result {
+	case waitResultSatisfied:
+		return waitExitSatisfied
+	case waitResultRejected:
+		return waitExitRejected
+	default:
+		return waitExitTimeout
+	}
+}
+
+func init() {
+	releaseWaitCmd.PersistentFlags().StringVar(&waitReleaseUuid, "release", "", "UUID of the release to wait for (required)")
+	releaseWaitCmd.PersistentFlags().StringArrayVar(&waitUntil, "until", []string{}, "Gate to wait for: lifecycle=<LIFECYCLE> or approval=<entry-uuid>:<APPROVED|DISAPPROVED>; separate alternatives with '|' (required, multiple allowed, all must be satisfied)")
+	releaseWaitCmd.PersistentFlags().DurationVar(&waitTimeout, "timeout", time.Hour, "Maximum time to wait, e.g. 30m or 2h (optional, default 1h)")
+	releaseWaitCmd.PersistentFlags().DurationVar(&waitInterval, "interval", 10*time.Second, "Polling interval (optional, default 10s)")
+	releaseWaitCmd.MarkPersistentFlagRequired("release")
+	releaseWaitCmd.MarkPersistentFlagRequired("until")
+	releaseCmd.AddCommand(releaseWaitCmd)
+}
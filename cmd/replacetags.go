@@ -184,10 +184,10 @@ func replaceTagsOnFile(replaceTagsVars *ReplaceTagsVars, substitutionMap *map[st
 	fileInfo, err := os.Stat(infile)
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		exitCommand(1)
 	} else if fileInfo.IsDir() {
 		fmt.Println("Error: infile must be a path to a file, not a directory!")
-		os.Exit(1)
+		exitCommand(1)
 	}
 	// Open infile if not directory:
 	var inFileOpened *os.File
@@ -196,7 +196,7 @@ func replaceTagsOnFile(replaceTagsVars *ReplaceTagsVars, substitutionMap *map[st
 	if inFileOpenedError != nil {
 		fmt.Println("Error opening infile: " + infile)
 		fmt.Println(inFileOpenedError)
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	// retrieve secrets and props from infile
@@ -209,7 +209,7 @@ func replaceTagsOnFile(replaceTagsVars *ReplaceTagsVars, substitutionMap *map[st
 	if inFileOpenedError != nil {
 		fmt.Println("Error opening infile: " + infile)
 		fmt.Println(inFileOpenedError)
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	// Parse infile and get slice of lines to be written to outfile/stdout
@@ -220,7 +220,7 @@ func replaceTagsOnFile(replaceTagsVars *ReplaceTagsVars, substitutionMap *map[st
 	if inFileCloseError != nil {
 		fmt.Println("Error closing infile: " + infile)
 		fmt.Println(inFileCloseError)
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	// write parsed lines to outfile/stdout if parsing did not fail
@@ -237,7 +237,7 @@ func replaceTagsOnFile(replaceTagsVars *ReplaceTagsVars, substitutionMap *map[st
 			if outFileOpenedError != nil {
 				fmt.Println("Error opening outfile: " + outfile)
 				fmt.Println(outFileOpenedError)
-				os.Exit(1)
+				exitCommand(1)
 			}
 		}
 
@@ -259,12 +259,12 @@ func replaceTagsOnFile(replaceTagsVars *ReplaceTagsVars, substitutionMap *map[st
 			if outFileCloseError != nil {
 				fmt.Println("Error closing outfile: " + outfile)
 				fmt.Println(outFileCloseError)
-				os.Exit(1)
+				exitCommand(1)
 			}
 		}
 	} else {
 		fmt.Println("Error parsing input file")
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	return retOut
@@ -285,32 +285,32 @@ func replaceTagsOnDirectory(indir *string, outdir *string, substitutionMap *map[
 	// If parsing files from input directory, an output directory path should be provided, not an output file path.
 	if len(outfile) > 0 {
 		fmt.Println("Error: please only provide '--outdirectory' flag (no '--outfile') when using '--indirectory' as input instead of '--infile'.")
-		os.Exit(1)
+		exitCommand(1)
 	}
 	// Check that outDirectory has value. Cannot write to stdout when parsing multiple files from a directory.
 	if len(outDirectory) == 0 {
 		fmt.Println("Error: '--outdirectory' flag is not set. Must supply a path to an output directory when using --indirectory flag.")
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	_, err := os.ReadDir(*outdir)
 	if err == nil && *outdir != *indir {
 		fmt.Println("Error: output directory already exists " + *outdir)
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	err1 := os.MkdirAll(*outdir, os.FileMode(0770))
 	if err1 != nil {
 		fmt.Println("Error: could not create directory " + *outdir)
 		fmt.Println(err1)
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	var fileNames []string
 	files, err := os.ReadDir(*indir)
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	for _, f := range files {
@@ -351,7 +351,7 @@ func scanDefenitionReferenceFile() map[string]string {
 	defFile, fileOpenErr := os.Open(definitionReferenceFile)
 	if fileOpenErr != nil {
 		fmt.Println(fileOpenErr)
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	// map to store definition images to their replacements -> will be applied on source files
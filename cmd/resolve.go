@@ -0,0 +1,401 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// componentCachePath overrides the location of the component identity cache.
+var componentCachePath string
+
+// noComponentCache turns the component identity cache off for reads and
+// writes. componentCacheTtl is how long a cached entry is used; zero keeps
+// entries until they are rejected by ReARM.
+var (
+	noComponentCache  bool
+	componentCacheTtl time.Duration
+)
+
+var (
+	resolveOrg     string
+	resolveRefresh bool
+)
+
+// componentFromCache is set when --component was filled in from the cache,
+// so a request ReARM rejects can be repeated by --vcsuri.
+var componentFromCache bool
+
+// ComponentIdentity is a component resolved from its VCS repository and
+// repository path, as printed by resolve component and stored in the cache.
+type ComponentIdentity struct {
+	Uuid       string `json:"uuid"`
+	Name       string `json:"name"`
+	Org        string `json:"org"`
+	Type       string `json:"type"`
+	VcsUri     string `json:"vcsUri"`
+	RepoPath   string `json:"repoPath,omitempty"`
+	ResolvedAt string `json:"resolvedAt"`
+	Source     string `json:"source,omitempty"`
+}
+
+// componentCache is the on-disk cache, keyed by componentCacheKey.
+type componentCache struct {
+	Components map[string]ComponentIdentity `json:"components"`
+}
+
+var resolveCmd = &cobra.Command{
+	Use:   "resolve",
+	Short: "Resolve identities of ReARM entities",
+	Long:  `Set of commands that look up ReARM entities from external identifiers and cache the result locally.`,
+}
+
+var resolveComponentCmd = &cobra.Command{
+	Use:   "component",
+	Short: "Resolve a component UUID from its VCS repository URI",
+	Long: `Looks up the component registered for --vcsuri and prints its UUID and metadata as
+JSON. The result is stored in a local cache; addrelease, getversion, getlatestrelease and
+syncbranches invoked later with the same --vcsuri and without --component send the cached
+UUID along with the VCS flags, and are repeated without it if ReARM rejects it. Cached
+results are returned without contacting ReARM unless --refresh is set.
+
+Components do not expose their repository path, so components of a monorepo, which
+share one repository, cannot be resolved this way; use --component for them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if debug == "true" {
+			fmt.Println("Using ReARM at", rearmUri)
+		}
+		if len(vcsUri) == 0 {
+			fmt.Fprintln(os.Stderr, "Error: --vcsuri is required")
+			exitCommand(2)
+		}
+		if !resolveRefresh {
+			if id, ok := lookupCachedComponent(vcsUri, repoPath); ok {
+				id.Source = "cache"
+				emitJson(id)
+				return
+			}
+		}
+		if len(resolveOrg) == 0 {
+			fmt.Fprintln(os.Stderr, "Error: --org is required when the component is not cached")
+			exitCommand(2)
+		}
+		id, err := resolveComponentIdentity(resolveOrg, vcsUri, repoPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			exitCommand(1)
+		}
+		if err := storeCachedComponent(*id); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not write component cache: %v\n", err)
+		}
+		id.Source = "server"
+		emitJson(id)
+	},
+}
+
+// resolveComponentIdentity finds the single component of org whose VCS
+// repository matches. Components do not expose their repository path, so a
+// repository path, or a repository shared by several components, cannot be
+// resolved this way.
+func resolveComponentIdentity(org, uri, path string) (*ComponentIdentity, error) {
+	wantPath := normalizeComponentRepoPath(path)
+	if wantPath != "" {
+		return nil, fmt.Errorf("ReARM does not expose the repository path of components, so the component of repository path %q cannot be resolved, use --component instead", wantPath)
+	}
+	query := `
+		query ($orgUuid: ID!, $componentType: ComponentType!) {
+			components(orgUuid: $orgUuid, componentType: $componentType) {
+				uuid
+				name
+				org
+				type
+				vcsRepositoryDetails {
+					uri
+				}
+			}
+		}
+	`
+	data, err := sendGraphQLRequest(query, map[string]interface{}{"orgUuid": org, "componentType": "ANY"}, rearmUri+"/graphql")
+	if err != nil {
+		return nil, err
+	}
+	var comps []struct {
+		Uuid                 string `json:"uuid"`
+		Name                 string `json:"name"`
+		Org                  string `json:"org"`
+		Type                 string `json:"type"`
+		VcsRepositoryDetails *struct {
+			Uri string `json:"uri"`
+		} `json:"vcsRepositoryDetails"`
+	}
+	if err := decodeInto(data["components"], &comps); err != nil {
+		return nil, err
+	}
+	wantUri := NormalizeVcsUri(uri)
+	var matches []ComponentIdentity
+	for _, c := range comps {
+		if c.VcsRepositoryDetails == nil || NormalizeVcsUri(c.VcsRepositoryDetails.Uri) != wantUri {
+			continue
+		}
+		matches = append(matches, ComponentIdentity{
+			Uuid:       c.Uuid,
+			Name:       c.Name,
+			Org:        c.Org,
+			Type:       c.Type,
+			VcsUri:     uri,
+			ResolvedAt: time.Now().UTC().Format(time.RFC3339),
+		})
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no component found for VCS repository %s", uri)
+	case 1:
+		return &matches[0], nil
+	default:
+		var uuids []string
+		for _, m := range matches {
+			uuids = append(uuids, m.Uuid)
+		}
+		return nil, fmt.Errorf("several components use VCS repository %s, use --component instead: %s", uri, strings.Join(uuids, ", "))
+	}
+}
+
+// NormalizeVcsUri reduces the usual spellings of a repository URI (scheme,
+// credentials, scp-style ssh, .git suffix, trailing slash, case) to one form.
+func NormalizeVcsUri(uri string) string {
+	u := strings.ToLower(strings.TrimSpace(uri))
+	if i := strings.Index(u, "://"); i >= 0 {
+		u = u[i+3:]
+	} else if at := strings.Index(u, "@"); at >= 0 {
+		// scp-style git@host:org/repo
+		u = strings.Replace(u[at+1:], ":", "/", 1)
+	}
+	if at := strings.Index(u, "@"); at >= 0 && at < strings.Index(u+"/", "/") {
+		u = u[at+1:]
+	}
+	u = strings.TrimSuffix(u, "/")
+	u = strings.TrimSuffix(u, ".git")
+	return u
+}
+
+// normalizeComponentRepoPath treats an empty path and the repository root
+// as the same thing.
+func normalizeComponentRepoPath(p string) string {
+	if strings.TrimSpace(p) == "" {
+		return ""
+	}
+	p = normalizeRepoPath(p)
+	if p == "." || p == "/" {
+		return ""
+	}
+	return p
+}
+
+func componentCacheFile() (string, error) {
+	if len(componentCachePath) > 0 {
+		return componentCachePath, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "rearm", "components.json"), nil
+}
+
+// componentCacheKey scopes cache entries to the ReARM instance and API key, so
+// one cache can serve several instances and a UUID resolved with one key is
+// never reused with another.
+func componentCacheKey(uri, path string) string {
+	return strings.TrimSuffix(rearmUri, "/") + "|" + apiKeyId + "|" + NormalizeVcsUri(uri) + "|" + normalizeComponentRepoPath(path)
+}
+
+func loadComponentCache() (*componentCache, error) {
+	cache := &componentCache{Components: map[string]ComponentIdentity{}}
+	file, err := componentCacheFile()
+	if err != nil {
+		return cache, err
+	}
+	raw, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return cache, err
+	}
+	if err := json.Unmarshal(raw, cache); err != nil {
+		return cache, fmt.Errorf("parsing %s: %w", file, err)
+	}
+	if cache.Components == nil {
+		cache.Components = map[string]ComponentIdentity{}
+	}
+	return cache, nil
+}
+
+// lookupCachedComponent returns the cached component for the repository
+// unless the cache is off or the entry is older than --component-cache-ttl.
+func lookupCachedComponent(uri, path string) (ComponentIdentity, bool) {
+	if noComponentCache {
+		return ComponentIdentity{}, false
+	}
+	cache, err := loadComponentCache()
+	if err != nil {
+		if debug == "true" {
+			fmt.Println("Ignoring component cache:", err)
+		}
+		return ComponentIdentity{}, false
+	}
+	id, ok := cache.Components[componentCacheKey(uri, path)]
+	if !ok {
+		return id, false
+	}
+	if componentCacheTtl > 0 {
+		resolvedAt, err := time.Parse(time.RFC3339, id.ResolvedAt)
+		if err != nil || time.Since(resolvedAt) > componentCacheTtl {
+			return ComponentIdentity{}, false
+		}
+	}
+	return id, true
+}
+
+// storeCachedComponent adds id to the cache.
+func storeCachedComponent(id ComponentIdentity) error {
+	if noComponentCache {
+		return nil
+	}
+	cache, err := loadComponentCache()
+	if err != nil {
+		return err
+	}
+	cache.Components[componentCacheKey(id.VcsUri, id.RepoPath)] = id
+	return writeComponentCache(cache)
+}
+
+// dropCachedComponent removes the entry of a repository from the cache.
+func dropCachedComponent(uri, path string) error {
+	cache, err := loadComponentCache()
+	if err != nil {
+		return err
+	}
+	delete(cache.Components, componentCacheKey(uri, path))
+	return writeComponentCache(cache)
+}
+
+// writeComponentCache replaces the cache file atomically so concurrent
+// pipeline steps never read a partial cache.
+func writeComponentCache(cache *componentCache) error {
+	file, err := componentCacheFile()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".components-*.json")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// applyCachedComponent fills in --component from the cache when a command was
+// given --vcsuri without --component and the repository was resolved within
+// --component-cache-ttl. The VCS flags are still sent, so when ReARM rejects
+// the cached UUID the request is repeated without it, see
+// cachedComponentRejected.
+func applyCachedComponent() {
+	if len(component) > 0 || len(vcsUri) == 0 {
+		return
+	}
+	id, ok := lookupCachedComponent(vcsUri, repoPath)
+	if !ok {
+		return
+	}
+	component = id.Uuid
+	componentFromCache = true
+	if debug == "true" {
+		fmt.Println("Using cached component", id.Uuid, "for", vcsUri)
+	}
+}
+
+// cachedComponentRejected reports whether a request that carried the cached
+// component failed with GraphQL errors. The cache entry is then dropped,
+// --component cleared and clearComponent called to remove the UUID from the
+// request input, so the caller can send the request once more and have ReARM
+// resolve the component by --vcsuri. Transport errors are not retried.
+func cachedComponentRejected(err error, clearComponent func()) bool {
+	if !componentFromCache || err == nil || !strings.HasPrefix(err.Error(), "GraphQL errors") {
+		return false
+	}
+	fmt.Fprintf(os.Stderr, "Warning: cached component %s was rejected, resolving by --vcsuri instead: %v\n", component, err)
+	if err := dropCachedComponent(vcsUri, repoPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not write component cache: %v\n", err)
+	}
+	component = ""
+	componentFromCache = false
+	clearComponent()
+	return true
+}
+
+// sendComponentRequest is sendRequest for requests that may carry the cached
+// component, repeating the request by --vcsuri when it is rejected.
+func sendComponentRequest(query string, variables map[string]interface{}, endpoint string, clearComponent func()) string {
+	data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
+	if cachedComponentRejected(err, clearComponent) {
+		data, err = sendGraphQLRequest(query, variables, rearmUri+"/graphql")
+	}
+	if err != nil {
+		printGqlError(err)
+		exitCommand(1)
+	}
+	jsonResponse, _ := json.Marshal(data[endpoint])
+	return string(jsonResponse)
+}
+
+func init() {
+	resolveComponentCmd.PersistentFlags().StringVar(&resolveOrg, "org", "", "UUID of the organization to search (required unless the component is cached)")
+	resolveComponentCmd.PersistentFlags().StringVar(&vcsUri, "vcsuri", "", "URI of VCS repository (required)")
+	resolveComponentCmd.PersistentFlags().StringVar(&repoPath, "repo-path", "", "Repository path for monorepo components (optional, only a root path can be resolved)")
+	resolveComponentCmd.PersistentFlags().BoolVar(&resolveRefresh, "refresh", false, "Resolve against ReARM even if the component is cached, and update the cache")
+
+	rootCmd.PersistentFlags().StringVar(&componentCachePath, "component-cache", "", "Path of the component identity cache written by resolve component (default is rearm/components.json in the user cache directory)")
+	rootCmd.PersistentFlags().BoolVar(&noComponentCache, "no-component-cache", false, "Neither read nor write the component identity cache")
+	rootCmd.PersistentFlags().DurationVar(&componentCacheTtl, "component-cache-ttl", 24*time.Hour, "How long a cached component identity is used before it is resolved again, 0 to keep it until ReARM rejects it")
+
+	resolveCmd.AddCommand(resolveComponentCmd)
+	rootCmd.AddCommand(resolveCmd)
+}
@@ -252,6 +252,7 @@ var rootCmd = &cobra.Command{
 	Long:  `CLI client for programmatic actions on Reliza's ReARM.`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		initConfig(cmd)
+		auditCommand = cmd.CommandPath()
 	},
 }
 
@@ -274,7 +275,7 @@ var loginCmd = &cobra.Command{
 
 		if err != nil {
 			fmt.Println(err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		configPath := filepath.Join(home, defaultConfigFilename+"."+configType)
@@ -284,7 +285,7 @@ var loginCmd = &cobra.Command{
 			//create new config file
 			if _, err := os.Create(configPath); err != nil { // perm 0666
 				fmt.Println(err)
-				os.Exit(1)
+				exitCommand(1)
 			}
 		}
 
@@ -294,7 +295,7 @@ var loginCmd = &cobra.Command{
 
 		if err := viper.WriteConfigAs(configPath); err != nil {
 			fmt.Println(err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 	},
 }
@@ -334,7 +335,7 @@ var addODeliverableCmd = &cobra.Command{
 			// now do some length validations and add elements
 			if len(odelBuildId) > 0 && len(odelBuildId) != len(odelId) {
 				fmt.Println("number of --odelBuildId flags must be either zero or match number of --odelid flags")
-				os.Exit(2)
+				exitCommand(2)
 			} else if len(odelBuildId) > 0 {
 				for i, abid := range odelBuildId {
 					softwareMetadatas[i]["buildId"] = abid
@@ -343,7 +344,7 @@ var addODeliverableCmd = &cobra.Command{
 
 			if len(odelBuildUri) > 0 && len(odelBuildUri) != len(odelId) {
 				fmt.Println("number of --odelbuildUri flags must be either zero or match number of --odelid flags")
-				os.Exit(2)
+				exitCommand(2)
 			} else if len(odelBuildUri) > 0 {
 				for i, aburi := range odelBuildUri {
 					softwareMetadatas[i]["buildUri"] = aburi
@@ -352,7 +353,7 @@ var addODeliverableCmd = &cobra.Command{
 
 			if len(odelCiMeta) > 0 && len(odelCiMeta) != len(odelId) {
 				fmt.Println("number of --odelcimeta flags must be either zero or match number of --odelid flags")
-				os.Exit(2)
+				exitCommand(2)
 			} else if len(odelCiMeta) > 0 {
 				for i, acm := range odelCiMeta {
 					softwareMetadatas[i]["cicdMeta"] = acm
@@ -361,7 +362,7 @@ var addODeliverableCmd = &cobra.Command{
 
 			if len(odelDigests) > 0 && len(odelDigests) != len(odelId) {
 				fmt.Println("number of --odeldigests flags must be either zero or match number of --odelid flags")
-				os.Exit(2)
+				exitCommand(2)
 			} else if len(odelDigests) > 0 {
 				for i, ad := range odelDigests {
 					adSpl := strings.Split(ad, ",")
@@ -371,7 +372,7 @@ var addODeliverableCmd = &cobra.Command{
 
 			if len(dateStart) > 0 && len(dateStart) != len(odelId) {
 				fmt.Println("number of --datestart flags must be either zero or match number of --odelid flags")
-				os.Exit(2)
+				exitCommand(2)
 			} else if len(dateStart) > 0 {
 				for i, ds := range dateStart {
 					softwareMetadatas[i]["dateFrom"] = ds
@@ -380,7 +381,7 @@ var addODeliverableCmd = &cobra.Command{
 
 			if len(dateEnd) > 0 && len(dateEnd) != len(odelId) {
 				fmt.Println("number of --dateEnd flags must be either zero or match number of --odelid flags")
-				os.Exit(2)
+				exitCommand(2)
 			} else if len(dateEnd) > 0 {
 				for i, de := range dateEnd {
 					softwareMetadatas[i]["dateTo"] = de
@@ -389,7 +390,7 @@ var addODeliverableCmd = &cobra.Command{
 
 			if len(odelPackage) > 0 && len(odelPackage) != len(odelId) {
 				fmt.Println("number of --odelpackage flags must be either zero or match number of --odelid flags")
-				os.Exit(2)
+				exitCommand(2)
 			} else if len(odelPackage) > 0 {
 				for i, ap := range odelPackage {
 					softwareMetadatas[i]["packageType"] = strings.ToUpper(ap)
@@ -402,7 +403,7 @@ var addODeliverableCmd = &cobra.Command{
 
 			if len(odelType) > 0 && len(odelType) != len(odelId) {
 				fmt.Println("number of --odeltype flags must be either zero or match number of --odelid flags")
-				os.Exit(2)
+				exitCommand(2)
 			} else if len(odelType) > 0 {
 				for i, at := range odelType {
 					outboundDeliverables[i]["type"] = at
@@ -411,7 +412,7 @@ var addODeliverableCmd = &cobra.Command{
 
 			if len(supportedOsArr) > 0 && len(supportedOsArr) != len(odelId) {
 				fmt.Println("number of --osarr flags must be either zero or match number of --odelid flags")
-				os.Exit(2)
+				exitCommand(2)
 			} else if len(supportedOsArr) > 0 {
 				for i, ad := range supportedOsArr {
 					adSpl := strings.Split(ad, ",")
@@ -420,7 +421,7 @@ var addODeliverableCmd = &cobra.Command{
 			}
 			if len(supportedCpuArchArr) > 0 && len(supportedCpuArchArr) != len(odelId) {
 				fmt.Println("number of --supportedcpuarcharr flags must be either zero or match number of --odelid flags")
-				os.Exit(2)
+				exitCommand(2)
 			} else if len(supportedCpuArchArr) > 0 {
 				for i, ad := range supportedCpuArchArr {
 					adSpl := strings.Split(ad, ",")
@@ -430,7 +431,7 @@ var addODeliverableCmd = &cobra.Command{
 
 			if len(odelVersion) > 0 && len(odelVersion) != len(odelId) {
 				fmt.Println("number of --odelversion flags must be either zero or match number of --odelid flags")
-				os.Exit(2)
+				exitCommand(2)
 			} else if len(odelVersion) > 0 {
 				for i, av := range odelVersion {
 					outboundDeliverables[i]["version"] = av
@@ -439,7 +440,7 @@ var addODeliverableCmd = &cobra.Command{
 
 			if len(odelPublisher) > 0 && len(odelPublisher) != len(odelId) {
 				fmt.Println("number of --odelpublisher flags must be either zero or match number of --odelid flags")
-				os.Exit(2)
+				exitCommand(2)
 			} else if len(odelPublisher) > 0 {
 				for i, ap := range odelPublisher {
 					outboundDeliverables[i]["publisher"] = ap
@@ -448,7 +449,7 @@ var addODeliverableCmd = &cobra.Command{
 
 			if len(odelGroup) > 0 && len(odelGroup) != len(odelId) {
 				fmt.Println("number of --odelgroup flags must be either zero or match number of --odelid flags")
-				os.Exit(2)
+				exitCommand(2)
 			} else if len(odelGroup) > 0 {
 				for i, ag := range odelGroup {
 					outboundDeliverables[i]["group"] = ag
@@ -457,7 +458,7 @@ var addODeliverableCmd = &cobra.Command{
 
 			if len(tagsArr) > 0 && len(tagsArr) != len(odelId) {
 				fmt.Println("number of --tagsarr flags must be either zero or match number of --odelid flags")
-				os.Exit(2)
+				exitCommand(2)
 			} else if len(tagsArr) > 0 {
 				for i, tags := range tagsArr {
 					tagPairs := strings.Split(tags, ",")
@@ -466,7 +467,7 @@ var addODeliverableCmd = &cobra.Command{
 						keyValue := strings.Split(tagPair, ":")
 						if len(keyValue) != 2 {
 							fmt.Println("Each tag should have key and value")
-							os.Exit(2)
+							exitCommand(2)
 						}
 						tags = append(tags, TagInput{
 							Key:   keyValue[0],
@@ -479,7 +480,7 @@ var addODeliverableCmd = &cobra.Command{
 
 			if len(identifiers) > 0 && len(identifiers) != len(odelId) {
 				fmt.Println("number of --identifiers flags must be either zero or match number of --odelid flags")
-				os.Exit(2)
+				exitCommand(2)
 			} else if len(identifiers) > 0 {
 				for i, delIdentifiers := range identifiers {
 					identityPairs := strings.Split(delIdentifiers, ",")
@@ -488,7 +489,7 @@ var addODeliverableCmd = &cobra.Command{
 						keyValue := strings.SplitN(identityPair, ":", 2)
 						if len(keyValue) != 2 {
 							fmt.Println("Each tag should have key and value")
-							os.Exit(2)
+							exitCommand(2)
 						}
 						identifiers = append(identifiers, Identifier{
 							IdType:  keyValue[0],
@@ -500,7 +501,7 @@ var addODeliverableCmd = &cobra.Command{
 			}
 			if len(odelArtsJson) > 0 && len(odelArtsJson) != len(odelId) {
 				fmt.Println("number of --odelartsjson flags must be either zero or match number of --odelid flags")
-				os.Exit(2)
+				exitCommand(2)
 			} else if len(odelArtsJson) > 0 {
 				for i, artifactsInputString := range odelArtsJson {
 					var artifactsInput []Artifact
@@ -522,10 +523,12 @@ var addODeliverableCmd = &cobra.Command{
 			fmt.Println(string(jsonBody))
 		}
 
+		variables := map[string]interface{}{"addODeliverableInput": body}
+		query := `mutation addOutboundDeliverablesProgrammatic($addODeliverableInput: AddODeliverableInput!) {addOutboundDeliverablesProgrammatic(deliverables:$addODeliverableInput) {` + RELEASE_GQL_DATA + `}}`
 		od := make(map[string]interface{})
 		od["operationName"] = "addOutboundDeliverablesProgrammatic"
-		od["variables"] = map[string]interface{}{"addODeliverableInput": body}
-		od["query"] = `mutation addOutboundDeliverablesProgrammatic($addODeliverableInput: AddODeliverableInput!) {addOutboundDeliverablesProgrammatic(deliverables:$addODeliverableInput) {` + RELEASE_GQL_DATA + `}}`
+		od["variables"] = variables
+		od["query"] = query
 
 		jsonOd, _ := json.Marshal(od)
 		operations := map[string]string{"operations": string(jsonOd)}
@@ -558,6 +561,7 @@ var addODeliverableCmd = &cobra.Command{
 			SetBasicAuth(apiKeyId, apiKey).
 			Post(rearmUri + "/graphql")
 
+		auditResty(query, variables, resp, err)
 		handleResponse(err, resp)
 	},
 }
@@ -641,6 +645,7 @@ var getVersionCmd = &cobra.Command{
 			fmt.Println("Using ReARM at", rearmUri)
 		}
 
+		applyCachedComponent()
 		resolveCommitsInput()
 
 		body := map[string]interface{}{"branch": branch}
@@ -707,7 +712,7 @@ var getVersionCmd = &cobra.Command{
 			plainCommits, err := base64.StdEncoding.DecodeString(commits)
 			if err != nil {
 				fmt.Println(err)
-				os.Exit(1)
+				exitCommand(1)
 			}
 			indCommits := strings.Split(string(plainCommits), "\n")
 			commitsInBody := make([]map[string]interface{}, len(indCommits)-1)
@@ -811,7 +816,7 @@ var getVersionCmd = &cobra.Command{
 		// it we keep the simple JSON post for back-compat with callers
 		// that don't carry SCE artifacts.
 		if sceArts == "" {
-			fmt.Println(sendRequest(query, variables, "getNewVersionProgrammatic"))
+			fmt.Println(sendComponentRequest(query, variables, "getNewVersionProgrammatic", func() { delete(body, "component") }))
 			return
 		}
 
@@ -822,7 +827,7 @@ var getVersionCmd = &cobra.Command{
 		var artInputs []Artifact
 		if err := json.Unmarshal([]byte(sceArts), &artInputs); err != nil {
 			fmt.Fprintln(os.Stderr, "Error parsing SCE Artifact Input: ", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 		processed := *processArtifactsInput(&artInputs,
 			"variables.GetNewVersionInput.sourceCodeEntry.artifacts.",
@@ -856,7 +861,7 @@ var getVersionCmd = &cobra.Command{
 		}
 		body["sourceCodeEntry"] = attachArtifactsToSourceCodeEntry(body["sourceCodeEntry"], processed)
 
-		fmt.Println(sendGraphQLMultipart(query, variables, "getNewVersionProgrammatic", locationMap, filesMap))
+		fmt.Println(sendGraphQLMultipart(query, variables, "getNewVersionProgrammatic", locationMap, filesMap, func() { delete(body, "component") }))
 	},
 }
 
@@ -884,7 +889,7 @@ var checkReleaseByHashCmd = &cobra.Command{
 		data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
 		if err != nil {
 			printGqlError(err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		if result, ok := data["getReleaseByHashProgrammatic"].(string); ok {
@@ -916,7 +921,7 @@ var releaseByVersionCmd = &cobra.Command{
 		data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
 		if err != nil {
 			printGqlError(err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		if result, ok := data["getReleaseByReleaseVersionProgrammatic"].(string); ok {
@@ -931,7 +936,7 @@ var releasecompletionfinalizerCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		if releaseId == "" {
 			fmt.Println("Error: --releaseid is required")
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		query := `mutation releasecompletionfinalizerProgrammatic($release: ID!) { releasecompletionfinalizerProgrammatic(release: $release) }`
@@ -944,10 +949,15 @@ var releasecompletionfinalizerCmd = &cobra.Command{
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
+	// known before flag validation, so failures there are logged with it
+	if c, _, err := rootCmd.Find(os.Args[1:]); err == nil {
+		auditCommand = c.CommandPath()
+	}
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		exitCommand(1)
 	}
+	recordAuditExit(0)
 }
 
 func processODelArtifactsInput(artifactsInput *[]Artifact, indexPrefix string, filesCounter *int,
@@ -961,6 +971,7 @@ func processODelArtifactsInput(artifactsInput *[]Artifact, indexPrefix string, f
 
 func processODelSingleArtifactInput(artInput *Artifact, indexPrefix string, fileJCounter int, filesCounter *int,
 	locationMap *map[string][]string, filesMap *map[string]interface{}) *Artifact {
+	withDetachedSignature(artInput)
 	// Process nested artifacts recursively
 	if len((*artInput).Artifacts) > 0 {
 		updIndex := indexPrefix + strconv.Itoa(fileJCounter) + ".artifacts."
@@ -969,13 +980,14 @@ func processODelSingleArtifactInput(artInput *Artifact, indexPrefix string, file
 	// File path is required for artifacts
 	if (*artInput).FilePath == "" {
 		fmt.Fprintln(os.Stderr, "Error: filePath is required for each artifact in odelartsjson")
-		os.Exit(1)
+		exitCommand(1)
 	}
 	fileBytes, err := os.ReadFile(artInput.FilePath)
 	if err != nil {
 		fmt.Println("Error reading file: ", err)
-		os.Exit(1)
+		exitCommand(1)
 	}
+	removeStagedSignature(artInput.FilePath)
 	*filesCounter++
 	currentIndex := strconv.Itoa(*filesCounter)
 
@@ -987,6 +999,7 @@ func processODelSingleArtifactInput(artInput *Artifact, indexPrefix string, file
 	artInput.File = nil
 	(*artInput).FilePath = ""
 	(*artInput).StripBom = strings.ToUpper(stripBom)
+	(*artInput).DigestRecords = withComputedDigests((*artInput).DigestRecords, fileBytes)
 	return artInput
 }
 
@@ -1024,6 +1037,9 @@ func init() {
 	addODeliverableCmd.PersistentFlags().StringArrayVar(&supportedCpuArchArr, "cpuarr", []string{}, "Deliverable supported CPU array (multiple allowed, use comma seprated values for each deliverable)")
 	addODeliverableCmd.PersistentFlags().StringArrayVar(&odelArtsJson, "odelartsjson", []string{}, "Deliverable Artifacts json array (multiple allowed, use a json array for each deliverable)")
 	addODeliverableCmd.PersistentFlags().StringVar(&stripBom, "stripbom", "true", "(Optional) Set --stripbom false to disable striping bom for digest matching.")
+	addODeliverableCmd.PersistentFlags().BoolVar(&skipDigestRecords, "skip-digests", false, "(Optional) Do not compute and send sha256/sha512 digest records for uploaded artifact files")
+	addODeliverableCmd.PersistentFlags().StringVar(&signingKeyPath, "signing-key", "", "(Optional) PEM ed25519/ECDSA or OpenSSH private key; uploaded files get a detached signature attached as a SIGNATURE artifact")
+	addODeliverableCmd.PersistentFlags().StringVar(&signingKeyId, "key-id", "", "(Optional) Key id recorded on signatures (default: derived from the key)")
 
 	releasecompletionfinalizerCmd.Flags().StringVar(&releaseId, "releaseid", "", "UUID of release to finalize (required)")
 	releasecompletionfinalizerCmd.MarkFlagRequired("releaseid")
@@ -1090,6 +1106,7 @@ func init() {
 	// release at create time). JSON array of ArtifactInput records —
 	// same shape addrelease accepts.
 	getVersionCmd.PersistentFlags().StringVar(&sceArts, "scearts", "", "(Optional) JSON array of Source-Code-Entry Artifacts to attach to the SCE at version-resolution time. Same shape as `rearm addrelease --scearts`. Use this to deliver SIGNATURE + SIGNED_PAYLOAD pairs early so component CEL gates that key on signature.state see a verdict in processRelease.")
+	getVersionCmd.PersistentFlags().BoolVar(&skipDigestRecords, "skip-digests", false, "(Optional) Do not compute and send sha256/sha512 digest records for uploaded artifact files")
 
 	// flags for check release by hash command
 	checkReleaseByHashCmd.PersistentFlags().StringVar(&hash, "hash", "", "Hash of artifact to check")
@@ -1150,7 +1167,7 @@ func sendRequestWithUri(query string, variables map[string]interface{}, endpoint
 	data, err := sendGraphQLRequest(query, variables, uri)
 	if err != nil {
 		printGqlError(err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	jsonResponse, _ := json.Marshal(data[endpoint])
@@ -1166,9 +1183,11 @@ func sendRequestWithUri(query string, variables map[string]interface{}, endpoint
 // Use this path when the GraphQL input carries any `file: Upload` references.
 // The locationMap and filesMap are populated by processArtifactsInput / its
 // kin — keys are the file-part name ("0", "1", …) and the location map points
-// at the JSON variable slot the part should fill in.
+// at the JSON variable slot the part should fill in. When the variables carry
+// a cached component that ReARM rejects, clearCachedComponent removes it and
+// the request is sent once more, see cachedComponentRejected.
 func sendGraphQLMultipart(query string, variables map[string]interface{}, operationName string,
-	locationMap map[string][]string, filesMap map[string]interface{}) string {
+	locationMap map[string][]string, filesMap map[string]interface{}, clearCachedComponent func()) string {
 
 	od := map[string]interface{}{
 		"operationName": operationName,
@@ -1203,14 +1222,15 @@ func sendGraphQLMultipart(query string, variables map[string]interface{}, operat
 		SetMultipartFormData(fileMapFd).
 		SetBasicAuth(apiKeyId, apiKey).
 		Post(rearmUri + "/graphql")
+	auditResty(query, variables, resp, err)
 
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "multipart request failed: %v\n", err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 	if resp.StatusCode() != 200 {
 		fmt.Fprintf(os.Stderr, "multipart request returned %d: %s\n", resp.StatusCode(), resp.String())
-		os.Exit(1)
+		exitCommand(1)
 	}
 	var gqlResp struct {
 		Data   map[string]interface{} `json:"data"`
@@ -1220,19 +1240,47 @@ func sendGraphQLMultipart(query string, variables map[string]interface{}, operat
 	}
 	if jsonErr := json.Unmarshal(resp.Body(), &gqlResp); jsonErr != nil {
 		fmt.Fprintf(os.Stderr, "failed to unmarshal multipart response: %v\n", jsonErr)
-		os.Exit(1)
+		exitCommand(1)
 	}
 	if len(gqlResp.Errors) > 0 {
+		// a cached component rejected by ReARM is retried by --vcsuri
+		errorsJSON, _ := json.Marshal(gqlResp.Errors)
+		if cachedComponentRejected(fmt.Errorf("GraphQL errors: %s", string(errorsJSON)), clearCachedComponent) {
+			return sendGraphQLMultipart(query, variables, operationName, locationMap, filesMap, clearCachedComponent)
+		}
 		fmt.Fprintln(os.Stderr, "GraphQL returned errors:")
 		for _, e := range gqlResp.Errors {
 			fmt.Fprintf(os.Stderr, "- %s\n", e.Message)
 		}
-		os.Exit(1)
+		exitCommand(1)
 	}
 	out, _ := json.Marshal(gqlResp.Data[operationName])
 	return string(out)
 }
 
+// graphQLResponseData reduces a raw multipart or REST response to the data and
+// error sendGraphQLRequest would return for it.
+func graphQLResponseData(resp *resty.Response, err error) (map[string]interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("request failed with status %d", resp.StatusCode())
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return nil, nil
+	}
+	if errs, ok := result["errors"]; ok {
+		errorsJSON, _ := json.Marshal(errs)
+		return nil, fmt.Errorf("GraphQL errors: %s", string(errorsJSON))
+	}
+	if data, ok := result["data"].(map[string]interface{}); ok {
+		return data, nil
+	}
+	return result, nil
+}
+
 func handleResponse(err error, resp *resty.Response) {
 	if debug == "true" {
 		// Explore response object
@@ -1261,24 +1309,24 @@ func handleResponse(err error, resp *resty.Response) {
 		fmt.Println("Status     :", resp.Status())
 		fmt.Println("Time       :", resp.Time())
 		fmt.Println("Received At:", resp.ReceivedAt())
-		os.Exit(1)
+		exitCommand(1)
 	}
 	if err != nil {
 		fmt.Println("Error      :", err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 	var gqlResp GraphQLResponse
 	gqlErr := json.Unmarshal(resp.Body(), &gqlResp)
 	if gqlErr != nil {
 		fmt.Printf("failed to unmarshal response: %v\n", gqlErr)
-		os.Exit(1)
+		exitCommand(1)
 	}
 	if len(gqlResp.Errors) > 0 {
 		fmt.Println("GraphQL returned errors:")
 		for _, e := range gqlResp.Errors {
 			fmt.Printf("- %s\n", e.Message)
 		}
-		os.Exit(1)
+		exitCommand(1)
 	}
 }
 
@@ -1294,7 +1342,7 @@ func initConfig(cmd *cobra.Command) {
 		home, err := homedir.Dir()
 		if err != nil {
 			fmt.Println(err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 		// Search config in home directory with name ".rearm" (without extension).
 		v.AddConfigPath(home)
@@ -1345,12 +1393,12 @@ func resolveCommitsInput() {
 	}
 	if commits != "" {
 		fmt.Println("Error: --commits and --commitsfile are mutually exclusive; specify only one.")
-		os.Exit(1)
+		exitCommand(1)
 	}
 	data, err := os.ReadFile(commitsFile)
 	if err != nil {
 		fmt.Printf("Error reading commitsfile %s: %v\n", commitsFile, err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 	commits = strings.TrimSpace(string(data))
 }
@@ -1387,7 +1435,8 @@ type GraphQLRequest struct {
 }
 
 // sendGraphQLRequest sends a GraphQL request using resty and returns the response data
-func sendGraphQLRequest(query string, variables map[string]interface{}, endpoint string) (map[string]interface{}, error) {
+func sendGraphQLRequest(query string, variables map[string]interface{}, endpoint string) (data map[string]interface{}, err error) {
+	defer func() { auditGraphQL(query, variables, data, err) }()
 	gqlReq := GraphQLRequest{
 		Query:     query,
 		Variables: variables,
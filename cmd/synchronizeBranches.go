@@ -46,28 +46,29 @@ var synchronizeBranchesCmd = &cobra.Command{
 		if debug == "true" {
 			fmt.Println("Using ReARM instance at", rearmUri)
 		}
+		applyCachedComponent()
 
 		if rawBranchesBase64File != "" {
 			if rawBranchesBase64 != "" {
 				fmt.Println("Error: --livebranches and --livebranchesfile are mutually exclusive; specify only one.")
-				os.Exit(1)
+				exitCommand(1)
 			}
 			data, err := os.ReadFile(rawBranchesBase64File)
 			if err != nil {
 				fmt.Printf("Error reading livebranchesfile %s: %v\n", rawBranchesBase64File, err)
-				os.Exit(1)
+				exitCommand(1)
 			}
 			rawBranchesBase64 = strings.TrimSpace(string(data))
 		}
 		if rawBranchesBase64 == "" {
 			fmt.Println("Error: either --livebranches or --livebranchesfile must be provided.")
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		plainBranches, err := base64.StdEncoding.DecodeString(rawBranchesBase64)
 		if err != nil {
 			fmt.Println(err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 		indBranches := strings.Split(string(plainBranches), "\n")
 
@@ -96,7 +97,10 @@ var synchronizeBranchesCmd = &cobra.Command{
 			}
 		`
 		variables := map[string]interface{}{"synchronizeBranchInput": sbi}
-		fmt.Println(sendRequest(query, variables, "synchronizeLiveBranches"))
+		fmt.Println(sendComponentRequest(query, variables, "synchronizeLiveBranches", func() {
+			sbi.Component = ""
+			variables["synchronizeBranchInput"] = sbi
+		}))
 	},
 }
 
@@ -69,7 +69,7 @@ Example TEI formats:
 		productReleaseUuid, err := resolveTEI(tei)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: Failed to retrieve product release UUID: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		fmt.Println(productReleaseUuid)
@@ -252,7 +252,7 @@ var fullTeaFlowCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := executeFullTeaFlow(tei); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 	},
 }
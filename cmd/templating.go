@@ -75,7 +75,7 @@ func substituteCopyBasedOnMap(inFileOpened *os.File, substitutionMap *map[string
 	parseMode = strings.ToLower(parseMode)
 	if parseMode != "simple" && parseMode != "extended" && parseMode != "strict" {
 		fmt.Println("Error: '" + parseMode + "' is not a valid parsemode. Must be either 'simple' or 'extended'")
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	sortedSubstitutions := *(sortSubstitutionMap(substitutionMap))
@@ -325,14 +325,14 @@ func parseLineOnScan(line string, sortedSubstitutions *[]KeyValueSorted, resolve
 			propVal, isPropExists := (*resolvedProperties)[psp.Key]
 			if !isPropExists {
 				fmt.Println("Property " + psp.Key + " not set; also make sure that --resolveprops flag is set to true; exiting...")
-				os.Exit(1)
+				exitCommand(1)
 			}
 			line = strings.ReplaceAll(line, psp.Wholetext, propVal)
 		} else if psp.Type == "SECRET" || psp.Type == "PLAINSECRET" {
 			rs, isSecretExists := (*resolvedSecrets)[psp.Key]
 			if !isSecretExists {
 				fmt.Println("Secret " + psp.Key + " not set or not available; also make sure that --resolveprops flag is set to true; exiting...")
-				os.Exit(1)
+				exitCommand(1)
 			}
 			if forDiff {
 				ts := fmt.Sprintf("%d", rs.Timestamp)
@@ -408,7 +408,7 @@ func parseLineOnScan(line string, sortedSubstitutions *[]KeyValueSorted, resolve
 	re := regexp.MustCompile(`(?i)^\s*image:`)
 	if !matchFound && parseMode == "strict" && re.MatchString(line) {
 		fmt.Println("Error: Failed to parse infile '" + inFileName + "'. Parse mode is set to 'strict' and cannot find artifact in substitution map: \n\t" + strings.TrimSpace(line))
-		os.Exit(1)
+		exitCommand(1)
 	}
 	return line
 }
@@ -507,7 +507,7 @@ func scanTagFile(tagSourceFile string, typeVal string) map[string]string {
 	if fileOpenErr != nil {
 		fmt.Println("Error opening tagSourceFile = " + tagSourceFile)
 		fmt.Println(fileOpenErr)
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	tagSourceMap := map[string]string{}
@@ -517,7 +517,7 @@ func scanTagFile(tagSourceFile string, typeVal string) map[string]string {
 		if ioReadErr != nil {
 			fmt.Println("Error opening tagFile = " + tagSourceFile)
 			fmt.Println(ioReadErr)
-			os.Exit(1)
+			exitCommand(1)
 		}
 		var bomJSON map[string]interface{}
 		json.Unmarshal(cycloneBytes, &bomJSON)
@@ -539,7 +539,7 @@ func extractComponentsFromCycloneJSON(bomJSON map[string]interface{}, tagSourceM
 		bomComponents = components.([]interface{})
 	} else {
 		fmt.Println("Error: CycloneDX BOM components are empty!")
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	// bomComponents := bomJSON["components"].([]interface{})
@@ -656,7 +656,7 @@ func scanTags(replaceTagsVars ReplaceTagsVars) map[string]string {
 		extractComponentsFromCycloneJSON(bomJSON, tagSourceMap)
 	} else {
 		fmt.Println("Scan Tags Failed! specify either tagsource or instance or product and version")
-		os.Exit(1)
+		exitCommand(1)
 	}
 	return tagSourceMap
 }
@@ -667,7 +667,7 @@ func getProductVersionCycloneDxExportV1(apiKeyId string, apiKey string, product
 	if len(product) <= 0 && (len(version) <= 0 || len(environment) <= 0) {
 		//throw error and exit
 		fmt.Println("Error: Product name and either version or environment must be provided!")
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	query := `
@@ -684,7 +684,7 @@ func getProductVersionCycloneDxExportV1(apiKeyId string, apiKey string, product
 	data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
 	if err != nil {
 		printGqlError(err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	if result, ok := data["exportAsBomProg"].(string); ok {
@@ -698,7 +698,7 @@ func getEnvironmentCycloneDxExportV1(apiKeyId string, apiKey string, environment
 	if len(environment) <= 0 {
 		//throw error and exit
 		fmt.Println("environment not specified!")
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	query := `
@@ -711,7 +711,7 @@ func getEnvironmentCycloneDxExportV1(apiKeyId string, apiKey string, environment
 	data, err := sendGraphQLRequest(query, variables, rearmUri+"/graphql")
 	if err != nil {
 		printGqlError(err)
-		os.Exit(1)
+		exitCommand(1)
 	}
 
 	if result, ok := data["exportAsBomProgByEnv"].(string); ok {
@@ -42,12 +42,12 @@ var helmvalues = &cobra.Command{
 			chartpath = filepath.Clean(args[0])
 		} else {
 			fmt.Println("Error: only 1 argument expected")
-			os.Exit(1)
+			exitCommand(1)
 		}
 		merged, err := mergeValues(valueFiles, chartpath)
 		if err != nil {
 			fmt.Println(err)
-			os.Exit(1)
+			exitCommand(1)
 		}
 
 		yamlData, err := yaml.Marshal(&merged)
@@ -63,7 +63,7 @@ var helmvalues = &cobra.Command{
 			if outFileOpenedError != nil {
 				fmt.Println("Error opening outfile: " + outfile)
 				fmt.Println(outFileOpenedError)
-				os.Exit(1)
+				exitCommand(1)
 			}
 			defer outFileOpened.Close()
 			outFileOpened.Write(yamlData)
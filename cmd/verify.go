@@ -0,0 +1,357 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	verifyFile              string
+	verifySignature         string
+	verifySignatureArtifact string
+	verifyPubkeyFile        string
+
+	verifyComponent        string
+	verifyVersion          string
+	verifyRelease          string
+	verifyDigest           string
+	verifyMinLifecycle     string
+	verifyRequireApprovals []string
+	verifyFormat           string
+)
+
+// Verdicts of verify file.
+const (
+	verdictPass = "PASS"
+	verdictFail = "FAIL"
+)
+
+// FileVerification is the machine-readable verdict of verify file.
+type FileVerification struct {
+	Verdict string            `json:"verdict"`
+	File    string            `json:"file,omitempty"`
+	Digests map[string]string `json:"digests"`
+	Release *ReleaseRef       `json:"release,omitempty"`
+	Matches []DigestOwner     `json:"matches"`
+	Checks  []VerifyCheck     `json:"checks"`
+}
+
+// DigestOwner is a deliverable or artifact of the release that carries the
+// verified digest.
+type DigestOwner struct {
+	Kind              string `json:"kind"`
+	Uuid              string `json:"uuid"`
+	DisplayIdentifier string `json:"displayIdentifier,omitempty"`
+	BelongsTo         string `json:"belongsTo,omitempty"`
+	Algo              string `json:"algo"`
+}
+
+type VerifyCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify artifacts against signatures and ReARM records",
+}
+
+var verifyArtifactCmd = &cobra.Command{
+	Use:   "artifact",
+	Short: "Verify a downloaded artifact against its detached signature",
+	Long: `Checks a local file against a detached signature produced by the --signing-key
+option of addartifact / addrelease. The signature is read from --signature or downloaded
+from ReARM with --signature-artifact (the UUID of the SIGNATURE artifact).
+
+PEM ed25519 and ECDSA public keys are verified in process. SSH public keys are verified
+with ssh-keygen -Y verify.
+
+Exits with code 1 when the signature does not match.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if (verifySignature == "") == (verifySignatureArtifact == "") {
+			fmt.Fprintln(os.Stderr, "Error: exactly one of --signature or --signature-artifact must be specified")
+			exitCommand(2)
+		}
+		data, err := os.ReadFile(verifyFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error reading file:", err)
+			exitCommand(1)
+		}
+		pubKey, err := os.ReadFile(verifyPubkeyFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error reading public key:", err)
+			exitCommand(1)
+		}
+		var sig []byte
+		if verifySignature != "" {
+			sig, err = os.ReadFile(verifySignature)
+		} else {
+			sig, _, err = downloadArtifactBytes(verifySignatureArtifact, true, 0)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error reading signature:", err)
+			exitCommand(1)
+		}
+		format, err := verifyDetachedSignature(data, sig, pubKey)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Verification of %s failed: %v\n", verifyFile, err)
+			exitCommand(1)
+		}
+		fmt.Printf("Verified %s (%s signature)\n", verifyFile, format)
+	},
+}
+
+var verifyFileCmd = &cobra.Command{
+	Use:   "file [path]",
+	Short: "Verify that a local file or image digest belongs to a release",
+	Long: `Computes the sha256 and sha512 digests of a local file, or takes the digest given
+with --digest (e.g. an image digest), and checks it against a release:
+
+  digest     the digest is recorded on a deliverable or an artifact of the release
+  lifecycle  the release is not REJECTED or CANCELLED and, with --min-lifecycle,
+             has reached at least that lifecycle
+  approval   every entry given with --require-approval is currently APPROVED
+
+The verdict is printed as JSON (or text with --format text) and the command exits
+with code 1 unless every check passed.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if (len(args) == 0) == (verifyDigest == "") {
+			fmt.Fprintln(os.Stderr, "Error: specify either a file path or --digest")
+			exitCommand(2)
+		}
+		releaseUuid := verifyRelease
+		if releaseUuid == "" {
+			if verifyComponent == "" || verifyVersion == "" {
+				fmt.Fprintln(os.Stderr, "Error: either --release or --component and --version must be specified")
+				exitCommand(2)
+			}
+			var err error
+			releaseUuid, err = resolveReleaseUuidByVersion(verifyComponent, verifyVersion)
+			if err != nil {
+				printGqlError(err)
+				exitCommand(1)
+			}
+			if releaseUuid == "" {
+				fmt.Fprintf(os.Stderr, "Error: release %s of component %s not found\n", verifyVersion, verifyComponent)
+				exitCommand(1)
+			}
+		}
+		minLifecycle := strings.ToUpper(verifyMinLifecycle)
+		if alias, ok := releaseLifecycleAliases[minLifecycle]; ok {
+			minLifecycle = alias
+		}
+		if minLifecycle != "" && lifecycleIndex(minLifecycle) < 0 {
+			fmt.Fprintf(os.Stderr, "Error: unknown lifecycle %q\n", verifyMinLifecycle)
+			exitCommand(2)
+		}
+
+		result := FileVerification{Digests: map[string]string{}, Matches: []DigestOwner{}, Checks: []VerifyCheck{}}
+		if len(args) == 1 {
+			result.File = args[0]
+			dl, err := hashFile(args[0])
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error reading file:", err)
+				exitCommand(1)
+			}
+			result.Digests["sha256"] = dl.Sha256
+			result.Digests["sha512"] = dl.Sha512
+		} else {
+			algo, value, err := parseDigestArg(verifyDigest)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err)
+				exitCommand(2)
+			}
+			result.Digests[algo] = value
+		}
+
+		var rlz struct {
+			releaseContent
+			ApprovalEvents []WaitApprovalEvent `json:"approvalEvents"`
+		}
+		if err := fetchReleaseContent(releaseUuid, waitReleaseGqlData, &rlz); err != nil {
+			printGqlError(err)
+			exitCommand(1)
+		}
+		result.Release = &ReleaseRef{Uuid: rlz.Uuid, Component: rlz.ComponentDetails.Name, Version: rlz.Version, Lifecycle: rlz.Lifecycle}
+		result.Matches = matchReleaseDigests(&rlz.releaseContent, result.Digests)
+		result.Checks = verifyReleaseChecks(result.Matches, WaitReleaseState{
+			Uuid:           rlz.Uuid,
+			Version:        rlz.Version,
+			Lifecycle:      rlz.Lifecycle,
+			ApprovalEvents: rlz.ApprovalEvents,
+		}, minLifecycle, verifyRequireApprovals)
+
+		result.Verdict = verdictPass
+		for _, c := range result.Checks {
+			if !c.Passed {
+				result.Verdict = verdictFail
+			}
+		}
+		if verifyFormat == "text" {
+			printFileVerification(result)
+		} else {
+			emitJson(result)
+		}
+		if result.Verdict != verdictPass {
+			exitCommand(1)
+		}
+	},
+}
+
+// parseDigestArg accepts algo:hex, image@algo:hex or a bare sha256 / sha512
+// hex digest.
+func parseDigestArg(digest string) (string, string, error) {
+	d := strings.TrimSpace(digest)
+	if i := strings.LastIndex(d, "@"); i >= 0 {
+		d = d[i+1:]
+	}
+	if algo, value, ok := strings.Cut(d, ":"); ok {
+		if s := normalizeSha256(algo, value); s != "" {
+			return "sha256", s, nil
+		}
+		if s := normalizeSha512(algo, value); s != "" {
+			return "sha512", s, nil
+		}
+		return "", "", fmt.Errorf("unsupported digest algorithm %q, use sha256 or sha512", algo)
+	}
+	switch len(d) {
+	case 64:
+		return "sha256", strings.ToLower(d), nil
+	case 128:
+		return "sha512", strings.ToLower(d), nil
+	}
+	return "", "", fmt.Errorf("cannot determine the algorithm of digest %q, use algo:digest", digest)
+}
+
+// matchReleaseDigests finds the deliverables and artifacts of the release
+// whose recorded digests equal one of the given ones.
+func matchReleaseDigests(rlz *releaseContent, digests map[string]string) []DigestOwner {
+	matchAlgo := func(algo string, digest string) string {
+		if d := normalizeSha256(algo, digest); d != "" && d == digests["sha256"] {
+			return "sha256"
+		}
+		if d := normalizeSha512(algo, digest); d != "" && d == digests["sha512"] {
+			return "sha512"
+		}
+		return ""
+	}
+	matches := []DigestOwner{}
+	for _, d := range rlz.deliverables() {
+		for _, dg := range d.SoftwareMetadata.Digests {
+			algo, value, ok := strings.Cut(dg, ":")
+			if !ok {
+				// bare digests are assumed to be sha256
+				algo, value = "sha256", dg
+			}
+			if a := matchAlgo(algo, value); a != "" {
+				matches = append(matches, DigestOwner{Kind: "DELIVERABLE", Uuid: d.Uuid, DisplayIdentifier: d.DisplayIdentifier, Algo: a})
+				break
+			}
+		}
+	}
+	for _, a := range rlz.allArtifacts() {
+		for _, dr := range a.DigestRecords {
+			if algo := matchAlgo(dr.Algo, dr.Digest); algo != "" {
+				matches = append(matches, DigestOwner{Kind: "ARTIFACT", Uuid: a.Uuid, DisplayIdentifier: a.DisplayIdentifier, BelongsTo: a.BelongsTo, Algo: algo})
+				break
+			}
+		}
+	}
+	return matches
+}
+
+func verifyReleaseChecks(matches []DigestOwner, state WaitReleaseState, minLifecycle string, approvals []string) []VerifyCheck {
+	checks := []VerifyCheck{}
+	digest := VerifyCheck{Name: "digest", Passed: len(matches) > 0}
+	if !digest.Passed {
+		digest.Detail = "digest is not recorded on any deliverable or artifact of the release"
+	}
+	checks = append(checks, digest)
+
+	lc := VerifyCheck{Name: "lifecycle", Passed: true, Detail: state.Lifecycle}
+	if state.Lifecycle == "REJECTED" || state.Lifecycle == "CANCELLED" {
+		lc.Passed = false
+		lc.Detail = "release is " + state.Lifecycle
+	} else if minLifecycle != "" && EvaluateWaitCondition(WaitCondition{Kind: "lifecycle", Value: minLifecycle}, state) != waitResultSatisfied {
+		lc.Passed = false
+		lc.Detail = "release is " + state.Lifecycle + ", required " + minLifecycle
+	}
+	checks = append(checks, lc)
+
+	for _, entry := range approvals {
+		c := VerifyCheck{Name: "approval:" + entry, Passed: true}
+		if EvaluateWaitCondition(WaitCondition{Kind: "approval", Entry: entry, Value: "APPROVED"}, state) != waitResultSatisfied {
+			c.Passed = false
+			c.Detail = "approval entry " + entry + " is not APPROVED"
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+func printFileVerification(r FileVerification) {
+	subject := r.File
+	if subject == "" {
+		for algo, d := range r.Digests {
+			subject = algo + ":" + d
+		}
+	}
+	fmt.Printf("%s %s against %s %s (%s)\n", r.Verdict, subject, r.Release.Component, r.Release.Version, r.Release.Uuid)
+	for _, m := range r.Matches {
+		fmt.Printf("  matched %s %s %s (%s)\n", strings.ToLower(m.Kind), m.DisplayIdentifier, m.Uuid, m.Algo)
+	}
+	for _, c := range r.Checks {
+		status := "ok"
+		if !c.Passed {
+			status = "FAILED"
+		}
+		if c.Detail != "" {
+			fmt.Printf("  %-10s %s: %s\n", c.Name, status, c.Detail)
+		} else {
+			fmt.Printf("  %-10s %s\n", c.Name, status)
+		}
+	}
+}
+
+func init() {
+	verifyArtifactCmd.PersistentFlags().StringVar(&verifyFile, "file", "", "Path to the artifact file")
+	verifyArtifactCmd.PersistentFlags().StringVar(&verifySignature, "signature", "", "Path to the detached signature")
+	verifyArtifactCmd.PersistentFlags().StringVar(&verifySignatureArtifact, "signature-artifact", "", "UUID of the SIGNATURE artifact to download instead of --signature")
+	verifyArtifactCmd.PersistentFlags().StringVar(&verifyPubkeyFile, "pubkey-file", "", "Path to the PEM or SSH public key")
+	verifyArtifactCmd.MarkPersistentFlagRequired("file")
+	verifyArtifactCmd.MarkPersistentFlagRequired("pubkey-file")
+
+	verifyFileCmd.PersistentFlags().StringVar(&verifyComponent, "component", "", "Component UUID, used with --version")
+	verifyFileCmd.PersistentFlags().StringVar(&verifyVersion, "version", "", "Release version")
+	verifyFileCmd.PersistentFlags().StringVar(&verifyRelease, "release", "", "UUID of the release, instead of --component and --version")
+	verifyFileCmd.PersistentFlags().StringVar(&verifyDigest, "digest", "", "Digest to verify instead of a file, e.g. sha256:<hex> or image@sha256:<hex>")
+	verifyFileCmd.PersistentFlags().StringVar(&verifyMinLifecycle, "min-lifecycle", "", "(Optional) Lifecycle the release must have reached, e.g. ASSEMBLED or GA")
+	verifyFileCmd.PersistentFlags().StringArrayVar(&verifyRequireApprovals, "require-approval", []string{}, "(Optional) Approval entry UUID that must be APPROVED, repeatable")
+	verifyFileCmd.PersistentFlags().StringVar(&verifyFormat, "format", "json", "Output format: json or text")
+
+	verifyCmd.AddCommand(verifyArtifactCmd)
+	verifyCmd.AddCommand(verifyFileCmd)
+	rootCmd.AddCommand(verifyCmd)
+}
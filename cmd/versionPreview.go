@@ -0,0 +1,86 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	previewSchema  string
+	previewCurrent string
+	previewBuildId string
+	previewDate    string
+)
+
+var versionPreviewCmd = &cobra.Command{
+	Use:   "preview",
+	Short: "Preview the next version locally without contacting ReARM",
+	Long: `Computes the next version for a version schema using a local implementation of
+the Reliza Versioning grammar. Supports the named schemas (semver, calver_*) and custom
+element forms such as Major.Minor.Patch, YYYY.0M.Micro or Branch.Micro, together with
+the --action, --pin, --modifier and --metadata options of getversion.
+
+The result is a preview: ReARM remains the source of truth for assigned versions.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		now := time.Now()
+		if previewDate != "" {
+			d, err := time.Parse("2006-01-02", previewDate)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error: --date must be in YYYY-MM-DD format")
+				exitCommand(2)
+			}
+			now = d
+		}
+		next, err := NextVersion(VersionSchemaInput{
+			Schema:   previewSchema,
+			Current:  previewCurrent,
+			Action:   action,
+			Pin:      versionPin,
+			Modifier: modifier,
+			Metadata: metadata,
+			Branch:   branch,
+			BuildId:  previewBuildId,
+			Now:      now,
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			exitCommand(1)
+		}
+		fmt.Println(next)
+	},
+}
+
+func init() {
+	versionPreviewCmd.PersistentFlags().StringVar(&previewSchema, "schema", "", "Version schema, named (semver, calver_ubuntu, ...) or custom (e.g. YYYY.0M.Micro)")
+	versionPreviewCmd.PersistentFlags().StringVar(&previewCurrent, "current", "", "(Optional) Current version; when empty the initial version of the schema is shown")
+	versionPreviewCmd.PersistentFlags().StringVar(&action, "action", "", "Bump action name: bump | bumppatch | bumpminor | bumpmajor | bumpdate")
+	versionPreviewCmd.PersistentFlags().StringVar(&versionPin, "pin", "", "(Optional) Version pin, e.g. 2.3.Patch")
+	versionPreviewCmd.PersistentFlags().StringVar(&modifier, "modifier", "", "Version modifier")
+	versionPreviewCmd.PersistentFlags().StringVar(&metadata, "metadata", "", "Version metadata")
+	versionPreviewCmd.PersistentFlags().StringVarP(&branch, "branch", "b", "", "Name of VCS Branch, used by the Branch element")
+	versionPreviewCmd.PersistentFlags().StringVar(&previewBuildId, "buildid", "", "Build id, used by the Buildid element")
+	versionPreviewCmd.PersistentFlags().StringVar(&previewDate, "date", "", "(Optional) Date to use for calver elements, YYYY-MM-DD (default: today)")
+	versionPreviewCmd.MarkPersistentFlagRequired("schema")
+
+	printversionCmd.AddCommand(versionPreviewCmd)
+}
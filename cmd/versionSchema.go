@@ -0,0 +1,436 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Local implementation of the Reliza Versioning schema grammar
+// (https://github.com/relizaio/versioning), used to preview what
+// getNewVersionProgrammatic would return without contacting ReARM.
+
+// KnownVersionSchemas maps the named schemas accepted by ReARM to their
+// element form.
+var KnownVersionSchemas = map[string]string{
+	"semver":         "Major.Minor.Patch-Modifier?+Metadata?",
+	"calver_reliza":  "YYYY.0M.Calvermodifier.Micro+Metadata?",
+	"calver_ubuntu":  "YY.0M.Micro",
+	"calver_yy_mm":   "YY.MM.Micro",
+	"calver_yyyy_mm": "YYYY.MM.Micro",
+	"calver_yy_0m":   "YY.0M.Micro",
+	"calver_yyyy_0m": "YYYY.0M.Micro",
+}
+
+const defaultCalverModifier = "Stable"
+
+// Version bump actions, matching the --action values of getversion.
+const (
+	VersionActionBump      = "bump"
+	VersionActionBumpPatch = "bumppatch"
+	VersionActionBumpMinor = "bumpminor"
+	VersionActionBumpMajor = "bumpmajor"
+	VersionActionBumpDate  = "bumpdate"
+)
+
+type versionElementKind int
+
+const (
+	elementLiteral versionElementKind = iota
+	elementCounter
+	elementDate
+	elementText
+)
+
+// counterRank orders numeric counters from most to least significant. Micro
+// is the calver name for the patch position.
+var counterRank = map[string]int{"major": 0, "minor": 1, "patch": 2, "micro": 2, "nano": 3}
+
+var dateTokens = map[string]string{
+	"yyyy": `\d{4}`, "yy": `\d{1,2}`, "0y": `\d{2}`,
+	"mm": `\d{1,2}`, "0m": `\d{2}`,
+	"ww": `\d{1,2}`, "0w": `\d{2}`,
+	"dd": `\d{1,2}`, "0d": `\d{2}`,
+}
+
+var textTokens = map[string]bool{"branch": true, "buildid": true, "buildenv": true, "modifier": true, "calvermodifier": true, "metadata": true}
+
+type versionElement struct {
+	// separator preceding the element; empty for the first one
+	sep      string
+	name     string // lower-cased token name, or the literal text
+	kind     versionElementKind
+	optional bool
+}
+
+// VersionSchemaInput carries everything NextVersion needs. Now defaults to the
+// current time when zero.
+type VersionSchemaInput struct {
+	Schema   string
+	Current  string
+	Action   string
+	Pin      string
+	Modifier string
+	Metadata string
+	Branch   string
+	BuildId  string
+	BuildEnv string
+	Now      time.Time
+}
+
+// ResolveVersionSchema returns the element form of a named schema, or the
+// input unchanged when it is already a custom element form.
+func ResolveVersionSchema(schema string) string {
+	if s, ok := KnownVersionSchemas[strings.ToLower(strings.TrimSpace(schema))]; ok {
+		return s
+	}
+	return strings.TrimSpace(schema)
+}
+
+func parseVersionSchema(schema string) ([]versionElement, error) {
+	var elements []versionElement
+	sep := ""
+	cur := strings.Builder{}
+	flush := func() error {
+		raw := cur.String()
+		cur.Reset()
+		if raw == "" {
+			return fmt.Errorf("empty element in schema")
+		}
+		el := versionElement{sep: sep}
+		if strings.HasSuffix(raw, "?") {
+			el.optional = true
+			raw = strings.TrimSuffix(raw, "?")
+		}
+		lower := strings.ToLower(raw)
+		switch {
+		case isCounterToken(lower):
+			el.kind, el.name = elementCounter, lower
+		case dateTokens[lower] != "":
+			el.kind, el.name = elementDate, lower
+		case textTokens[lower]:
+			el.kind, el.name = elementText, lower
+		default:
+			el.kind, el.name = elementLiteral, raw
+		}
+		elements = append(elements, el)
+		return nil
+	}
+	for _, r := range schema {
+		if isVersionSeparator(r) {
+			if err := flush(); err != nil {
+				return nil, fmt.Errorf("invalid schema %q: %v", schema, err)
+			}
+			sep = string(r)
+			continue
+		}
+		cur.WriteRune(r)
+	}
+	if err := flush(); err != nil {
+		return nil, fmt.Errorf("invalid schema %q: %v", schema, err)
+	}
+	return elements, nil
+}
+
+func isCounterToken(token string) bool {
+	_, ok := counterRank[token]
+	return ok
+}
+
+func isVersionSeparator(r rune) bool {
+	return r == '.' || r == '-' || r == '_' || r == '+'
+}
+
+func elementPattern(el versionElement) string {
+	switch el.kind {
+	case elementCounter:
+		return `\d+`
+	case elementDate:
+		return dateTokens[el.name]
+	case elementText:
+		if el.name == "metadata" {
+			return `[0-9A-Za-z.\-]+`
+		}
+		return `[0-9A-Za-z\-]+?`
+	default:
+		return regexp.QuoteMeta(el.name)
+	}
+}
+
+// parseVersionAgainstSchema splits an existing version into element values
+// following the schema, with an empty string for omitted optional elements.
+func parseVersionAgainstSchema(elements []versionElement, version string) ([]string, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, el := range elements {
+		part := regexp.QuoteMeta(el.sep) + "(" + elementPattern(el) + ")"
+		if el.optional {
+			part = "(?:" + part + ")?"
+		}
+		b.WriteString(part)
+	}
+	b.WriteString("$")
+	m := regexp.MustCompile(b.String()).FindStringSubmatch(version)
+	if m == nil {
+		return nil, fmt.Errorf("version %q does not match schema", version)
+	}
+	return m[1:], nil
+}
+
+func dateValue(token string, now time.Time) string {
+	_, week := now.ISOWeek()
+	switch token {
+	case "yyyy":
+		return strconv.Itoa(now.Year())
+	case "yy":
+		return strconv.Itoa(now.Year() % 100)
+	case "0y":
+		return fmt.Sprintf("%02d", now.Year()%100)
+	case "mm":
+		return strconv.Itoa(int(now.Month()))
+	case "0m":
+		return fmt.Sprintf("%02d", int(now.Month()))
+	case "ww":
+		return strconv.Itoa(week)
+	case "0w":
+		return fmt.Sprintf("%02d", week)
+	case "dd":
+		return strconv.Itoa(now.Day())
+	case "0d":
+		return fmt.Sprintf("%02d", now.Day())
+	}
+	return ""
+}
+
+var branchCleaner = regexp.MustCompile(`[^0-9A-Za-z\-]+`)
+
+// cleanBranchName makes a VCS branch name usable as a version element, e.g.
+// feature/login_page becomes feature-login-page.
+func cleanBranchName(branch string) string {
+	b := strings.TrimPrefix(strings.TrimPrefix(branch, "refs/heads/"), "origin/")
+	return strings.Trim(branchCleaner.ReplaceAllString(b, "-"), "-")
+}
+
+func sameNumber(a, b string) bool {
+	ai, errA := strconv.Atoi(a)
+	bi, errB := strconv.Atoi(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return ai == bi
+}
+
+// NextVersion computes the version ReARM would assign for the given schema,
+// current version and action. An empty Current yields the initial version of
+// the schema. Date elements are taken from Now; when they differ from Current
+// the counters are reset instead of bumped.
+func NextVersion(in VersionSchemaInput) (string, error) {
+	elements, err := parseVersionSchema(ResolveVersionSchema(in.Schema))
+	if err != nil {
+		return "", err
+	}
+	now := in.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	action := strings.ToLower(in.Action)
+	if action == "" {
+		action = VersionActionBump
+	}
+	switch action {
+	case VersionActionBump, VersionActionBumpPatch, VersionActionBumpMinor, VersionActionBumpMajor, VersionActionBumpDate:
+	default:
+		return "", fmt.Errorf("unknown action %q", in.Action)
+	}
+
+	values := make([]string, len(elements))
+	initial := in.Current == ""
+	if !initial {
+		values, err = parseVersionAgainstSchema(elements, in.Current)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// resetBelow is the counter rank from which counters restart at zero
+	// because a more significant element changed; -1 means nothing changed.
+	resetBelow := -1
+	markReset := func(idx int) {
+		// counters after a changed non-counter element restart too
+		rank := 0
+		for j := 0; j < idx; j++ {
+			if elements[j].kind == elementCounter && counterRank[elements[j].name]+1 > rank {
+				rank = counterRank[elements[j].name] + 1
+			}
+		}
+		if resetBelow == -1 || rank < resetBelow {
+			resetBelow = rank
+		}
+	}
+
+	if in.Pin != "" {
+		pinElements, err := parseVersionSchema(in.Pin)
+		if err != nil {
+			return "", err
+		}
+		// the pin may leave out trailing optional elements such as Modifier?
+		if len(pinElements) > len(elements) {
+			return "", fmt.Errorf("version pin %q does not match schema %q", in.Pin, in.Schema)
+		}
+		for _, el := range elements[len(pinElements):] {
+			if !el.optional {
+				return "", fmt.Errorf("version pin %q does not match schema %q", in.Pin, in.Schema)
+			}
+		}
+		for i, pe := range pinElements {
+			if pe.kind != elementLiteral {
+				continue
+			}
+			if initial || !sameNumber(values[i], pe.name) {
+				values[i] = pe.name
+				if elements[i].kind == elementCounter {
+					if r := counterRank[elements[i].name] + 1; resetBelow == -1 || r < resetBelow {
+						resetBelow = r
+					}
+				} else {
+					markReset(i)
+				}
+			}
+			// a pinned element is fixed, demote it so it is not bumped below
+			elements[i].kind = elementLiteral
+			elements[i].name = pe.name
+		}
+	}
+
+	for i, el := range elements {
+		switch el.kind {
+		case elementDate:
+			today := dateValue(el.name, now)
+			if initial || !sameNumber(values[i], today) {
+				markReset(i)
+			}
+			values[i] = today
+		case elementText:
+			switch el.name {
+			case "branch":
+				if in.Branch != "" {
+					if nb := cleanBranchName(in.Branch); initial || nb != values[i] {
+						markReset(i)
+						values[i] = nb
+					}
+				} else if initial {
+					return "", fmt.Errorf("schema contains Branch but no branch was given")
+				}
+			case "modifier":
+				values[i] = in.Modifier
+			case "calvermodifier":
+				values[i] = in.Modifier
+				if values[i] == "" {
+					values[i] = defaultCalverModifier
+				}
+			case "metadata":
+				values[i] = in.Metadata
+			case "buildid":
+				values[i] = in.BuildId
+			case "buildenv":
+				values[i] = in.BuildEnv
+			}
+		}
+	}
+
+	if initial {
+		for i, el := range elements {
+			if el.kind == elementCounter {
+				values[i] = "0"
+			}
+		}
+		return renderVersion(elements, values)
+	}
+
+	if resetBelow >= 0 {
+		for i, el := range elements {
+			if el.kind == elementCounter && counterRank[el.name] >= resetBelow {
+				values[i] = "0"
+			}
+		}
+		return renderVersion(elements, values)
+	}
+
+	target := -1
+	switch action {
+	case VersionActionBump, VersionActionBumpDate:
+		// bumpdate with an unchanged date falls back to a regular bump
+		for _, el := range elements {
+			if el.kind == elementCounter && counterRank[el.name] > target {
+				target = counterRank[el.name]
+			}
+		}
+		if target == -1 {
+			return "", fmt.Errorf("schema %q has no element that can be bumped", in.Schema)
+		}
+	case VersionActionBumpMajor:
+		target = counterRank["major"]
+	case VersionActionBumpMinor:
+		target = counterRank["minor"]
+	case VersionActionBumpPatch:
+		target = counterRank["patch"]
+	}
+	found := false
+	for i, el := range elements {
+		if el.kind != elementCounter {
+			continue
+		}
+		rank := counterRank[el.name]
+		if rank == target {
+			n, _ := strconv.Atoi(values[i])
+			values[i] = strconv.Itoa(n + 1)
+			found = true
+		} else if rank > target {
+			values[i] = "0"
+		}
+	}
+	if !found {
+		return "", fmt.Errorf("action %s cannot be applied to schema %q", action, in.Schema)
+	}
+	return renderVersion(elements, values)
+}
+
+func renderVersion(elements []versionElement, values []string) (string, error) {
+	var b strings.Builder
+	for i, el := range elements {
+		v := values[i]
+		if el.kind == elementLiteral && v == "" {
+			v = el.name
+		}
+		if v == "" {
+			if el.optional {
+				continue
+			}
+			return "", fmt.Errorf("no value for required element %s", el.name)
+		}
+		if b.Len() > 0 {
+			b.WriteString(el.sep)
+		}
+		b.WriteString(v)
+	}
+	return b.String(), nil
+}
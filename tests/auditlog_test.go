@@ -0,0 +1,170 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package tests
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/relizaio/rearm/cmd"
+)
+
+func TestAuditOperation(t *testing.T) {
+	cases := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{"named mutation", "mutation addReleaseProgrammatic($releaseInputProg: ReleaseInputProg!) {addReleaseProgrammatic(release:$releaseInputProg) {uuid}}", "addReleaseProgrammatic"},
+		{"leading whitespace", "\n\t\tmutation ($approvals: ReleaseApprovalProgrammaticInput!) {\n\t\t\tapproveReleaseProgrammatic(release: $approvals) {uuid}}", "approveReleaseProgrammatic"},
+		{"anonymous mutation", "mutation { syncBranches { uuid } }", "syncBranches"},
+		{"query", "query ($releaseUuid: ID!) { release(releaseUuid: $releaseUuid) { uuid } }", ""},
+		{"shorthand query", "{ release(releaseUuid: \"x\") { uuid } }", ""},
+		{"mutation word in a query", "query { mutationLog { uuid } }", ""},
+		{"mutation as a prefix", "mutations { addRelease { uuid } }", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := cmd.AuditOperation(c.query); actual != c.expected {
+				t.Fatalf("expected %q, got %q", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRedactAuditValue(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    interface{}
+		expected interface{}
+	}{
+		{"plain values kept",
+			map[string]interface{}{"version": "1.0.0", "branch": "main"},
+			map[string]interface{}{"version": "1.0.0", "branch": "main"}},
+		{"sensitive keys redacted",
+			map[string]interface{}{"secret": "s", "apiKey": "k", "userPassword": "p", "accessToken": "t", "privateKey": "pk", "tlsCert": "c", "credentials": "cr"},
+			map[string]interface{}{"secret": "[REDACTED]", "apiKey": "[REDACTED]", "userPassword": "[REDACTED]", "accessToken": "[REDACTED]", "privateKey": "[REDACTED]", "tlsCert": "[REDACTED]", "credentials": "[REDACTED]"}},
+		{"nested maps and lists",
+			map[string]interface{}{"input": []interface{}{map[string]interface{}{"name": "a", "token": "t"}}},
+			map[string]interface{}{"input": []interface{}{map[string]interface{}{"name": "a", "token": "[REDACTED]"}}}},
+		{"sensitive object redacted as a whole",
+			map[string]interface{}{"credential": map[string]interface{}{"user": "u"}},
+			map[string]interface{}{"credential": "[REDACTED]"}},
+		{"null sensitive value kept",
+			map[string]interface{}{"secret": nil},
+			map[string]interface{}{"secret": nil}},
+		{"scalar", "value", "value"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := cmd.RedactAuditValue(c.input); !reflect.DeepEqual(actual, c.expected) {
+				t.Fatalf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNewAuditExitRecord(t *testing.T) {
+	cases := []struct {
+		name   string
+		code   int
+		status string
+	}{
+		{"success", 0, "ok"},
+		{"failure", 1, "error"},
+		{"flag validation", 2, "error"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rec := cmd.NewAuditExitRecord("rearm addrelease", c.code)
+			if rec.Operation != "exit" || rec.Command != "rearm addrelease" || rec.Status != c.status {
+				t.Fatalf("unexpected record %+v", rec)
+			}
+			if rec.ExitStatus == nil || *rec.ExitStatus != c.code {
+				t.Fatalf("expected exit status %d, got %v", c.code, rec.ExitStatus)
+			}
+		})
+	}
+}
+
+// TestAuditLogExitStatus runs the CLI and checks that every invocation ends
+// with an exit record holding the exit status of the process.
+func TestAuditLogExitStatus(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the CLI")
+	}
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "rearm")
+	build := exec.Command("go", "build", "-o", bin, "..")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("building the CLI: %v\n%s", err, out)
+	}
+	logPath := filepath.Join(dir, "audit.jsonl")
+	cases := []struct {
+		args    []string
+		command string
+		code    int
+	}{
+		{[]string{"version"}, "rearm version", 0},
+		{[]string{"pullrequest", "show", "--component", "c"}, "rearm pullrequest show", 2},
+		{[]string{"pullrequest", "close"}, "rearm pullrequest close", 1},
+	}
+	for _, c := range cases {
+		run := exec.Command(bin, append(c.args, "--audit-log", logPath)...)
+		run.Env = append(os.Environ(), "HOME="+dir)
+		err := run.Run()
+		code := 0
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			code = exitErr.ExitCode()
+		} else if err != nil {
+			t.Fatalf("running %v: %v", c.args, err)
+		}
+		if code != c.code {
+			t.Fatalf("%v: expected exit code %d, got %d", c.args, c.code, code)
+		}
+	}
+
+	f, err := os.Open(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var recs []cmd.AuditRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec cmd.AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, rec)
+	}
+	if len(recs) != len(cases) {
+		t.Fatalf("expected %d records, got %d", len(cases), len(recs))
+	}
+	for i, c := range cases {
+		rec := recs[i]
+		if rec.Operation != "exit" || rec.Command != c.command || rec.ExitStatus == nil || *rec.ExitStatus != c.code {
+			t.Fatalf("%v: unexpected record %+v", c.args, rec)
+		}
+	}
+}
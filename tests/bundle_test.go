@@ -0,0 +1,163 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package tests
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relizaio/rearm/cmd"
+)
+
+type bundleEntry struct {
+	name     string
+	body     string
+	typeflag byte
+	linkname string
+}
+
+// writeTestBundle writes a gzipped tarball with a manifest listing files,
+// followed by entries.
+func writeTestBundle(t *testing.T, files map[string]string, entries []bundleEntry) string {
+	t.Helper()
+	manifest := cmd.BundleManifest{BundleVersion: 1}
+	for p, body := range files {
+		sum := sha256.Sum256([]byte(body))
+		manifest.Files = append(manifest.Files, cmd.BundleFile{Path: p, Size: int64(len(body)), Sha256: hex.EncodeToString(sum[:])})
+		entries = append([]bundleEntry{{name: p, body: body}}, entries...)
+	}
+	raw, _ := json.Marshal(manifest)
+	entries = append([]bundleEntry{{name: "manifest.json", body: string(raw)}}, entries...)
+
+	out := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	f, err := os.Create(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0644, Size: int64(len(e.body)), Typeflag: tar.TypeReg}
+		if e.typeflag != 0 {
+			hdr.Typeflag = e.typeflag
+			hdr.Linkname = e.linkname
+			hdr.Size = 0
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Size > 0 {
+			if _, err := tw.Write([]byte(e.body)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestExtractBundle(t *testing.T) {
+	cases := []struct {
+		name    string
+		entries []bundleEntry
+		err     string
+	}{
+		{"listed files only", nil, ""},
+		{"parent directory entry", []bundleEntry{{name: "../evil.txt", body: "x"}}, "points outside of the bundle"},
+		{"nested parent directory entry", []bundleEntry{{name: "artifacts/../../evil.txt", body: "x"}}, "points outside of the bundle"},
+		{"absolute entry", []bundleEntry{{name: "/tmp/evil.txt", body: "x"}}, "points outside of the bundle"},
+		{"symlink entry", []bundleEntry{{name: "artifacts/link", typeflag: tar.TypeSymlink, linkname: "/etc/passwd"}}, "unexpected entry"},
+		{"hard link entry", []bundleEntry{{name: "artifacts/link", typeflag: tar.TypeLink, linkname: "release.json"}}, "unexpected entry"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			bundle := writeTestBundle(t, map[string]string{"release.json": "{}"}, c.entries)
+			dir, manifest, err := cmd.ExtractBundle(bundle)
+			if dir != "" {
+				defer os.RemoveAll(dir)
+			}
+			if c.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if len(manifest.Files) != 1 {
+					t.Fatalf("expected 1 manifest file, got %d", len(manifest.Files))
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Fatalf("expected error containing %q, got %v", c.err, err)
+			}
+			if dir != "" {
+				t.Fatalf("expected no directory on error, got %s", dir)
+			}
+		})
+	}
+}
+
+func TestCheckBundleFiles(t *testing.T) {
+	cases := []struct {
+		name     string
+		entries  []bundleEntry
+		tamper   map[string]string
+		problems []string
+	}{
+		{"clean bundle", nil, nil, nil},
+		{"unlisted file", []bundleEntry{{name: "artifacts/extra.txt", body: "x"}}, nil, []string{"artifacts/extra.txt: not listed in the manifest"}},
+		{"modified file", nil, map[string]string{"release.json": `{"uuid":"other"}`}, []string{"release.json: sha256 mismatch"}},
+		{"missing file", nil, map[string]string{"release.json": ""}, []string{"release.json: missing"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			bundle := writeTestBundle(t, map[string]string{"release.json": "{}"}, c.entries)
+			dir, manifest, err := cmd.ExtractBundle(bundle)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer os.RemoveAll(dir)
+			for p, body := range c.tamper {
+				target := filepath.Join(dir, p)
+				if body == "" {
+					err = os.Remove(target)
+				} else {
+					err = os.WriteFile(target, []byte(body), 0644)
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			problems := cmd.CheckBundleFiles(dir, manifest)
+			if strings.Join(problems, "; ") != strings.Join(c.problems, "; ") {
+				t.Fatalf("expected problems %v, got %v", c.problems, problems)
+			}
+		})
+	}
+}
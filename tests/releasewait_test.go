@@ -0,0 +1,58 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/relizaio/rearm/cmd"
+)
+
+func TestEvaluateWaitCondition(t *testing.T) {
+	approved := cmd.WaitApprovalEvent{ApprovalEntry: "e", State: "APPROVED"}
+	disapproved := cmd.WaitApprovalEvent{ApprovalEntry: "e", State: "DISAPPROVED"}
+	unset := cmd.WaitApprovalEvent{ApprovalEntry: "e", State: "UNSET"}
+	other := cmd.WaitApprovalEvent{ApprovalEntry: "other", State: "DISAPPROVED"}
+	cases := []struct {
+		name      string
+		condition cmd.WaitCondition
+		state     cmd.WaitReleaseState
+		expected  string
+	}{
+		{"approved awaited, no events", cmd.WaitCondition{Kind: "approval", Entry: "e", Value: "APPROVED"}, cmd.WaitReleaseState{Lifecycle: "DRAFT"}, "PENDING"},
+		{"approved awaited, approved", cmd.WaitCondition{Kind: "approval", Entry: "e", Value: "APPROVED"}, cmd.WaitReleaseState{ApprovalEvents: []cmd.WaitApprovalEvent{approved}}, "SATISFIED"},
+		{"approved awaited, disapproved", cmd.WaitCondition{Kind: "approval", Entry: "e", Value: "APPROVED"}, cmd.WaitReleaseState{ApprovalEvents: []cmd.WaitApprovalEvent{disapproved}}, "REJECTED"},
+		{"disapproved awaited, disapproved", cmd.WaitCondition{Kind: "approval", Entry: "e", Value: "DISAPPROVED"}, cmd.WaitReleaseState{ApprovalEvents: []cmd.WaitApprovalEvent{disapproved}}, "SATISFIED"},
+		{"disapproved awaited, approved", cmd.WaitCondition{Kind: "approval", Entry: "e", Value: "DISAPPROVED"}, cmd.WaitReleaseState{ApprovalEvents: []cmd.WaitApprovalEvent{approved}}, "REJECTED"},
+		{"latest event wins", cmd.WaitCondition{Kind: "approval", Entry: "e", Value: "APPROVED"}, cmd.WaitReleaseState{ApprovalEvents: []cmd.WaitApprovalEvent{disapproved, approved}}, "SATISFIED"},
+		{"reset to unset is pending", cmd.WaitCondition{Kind: "approval", Entry: "e", Value: "DISAPPROVED"}, cmd.WaitReleaseState{ApprovalEvents: []cmd.WaitApprovalEvent{approved, unset}}, "PENDING"},
+		{"other entry ignored", cmd.WaitCondition{Kind: "approval", Entry: "e", Value: "APPROVED"}, cmd.WaitReleaseState{ApprovalEvents: []cmd.WaitApprovalEvent{other}}, "PENDING"},
+		{"cancelled release", cmd.WaitCondition{Kind: "approval", Entry: "e", Value: "APPROVED"}, cmd.WaitReleaseState{Lifecycle: "CANCELLED"}, "REJECTED"},
+		{"lifecycle reached", cmd.WaitCondition{Kind: "lifecycle", Value: "ASSEMBLED"}, cmd.WaitReleaseState{Lifecycle: "ASSEMBLED"}, "SATISFIED"},
+		{"lifecycle passed", cmd.WaitCondition{Kind: "lifecycle", Value: "ASSEMBLED"}, cmd.WaitReleaseState{Lifecycle: "GENERAL_AVAILABILITY"}, "SATISFIED"},
+		{"lifecycle not reached", cmd.WaitCondition{Kind: "lifecycle", Value: "GENERAL_AVAILABILITY"}, cmd.WaitReleaseState{Lifecycle: "DRAFT"}, "PENDING"},
+		{"lifecycle rejected", cmd.WaitCondition{Kind: "lifecycle", Value: "GENERAL_AVAILABILITY"}, cmd.WaitReleaseState{Lifecycle: "REJECTED"}, "REJECTED"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := cmd.EvaluateWaitCondition(c.condition, c.state); actual != c.expected {
+				t.Fatalf("expected %s, got %s", c.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+/*
+The MIT License (MIT)
+
+Copyright (c) 2020 - 2026 Reliza Incorporated (Reliza (tm), https://reliza.io)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"),
+to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/relizaio/rearm/cmd"
+)
+
+var versionTestDate = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+
+func TestNextVersion(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    cmd.VersionSchemaInput
+		expected string
+	}{
+		{"semver initial", cmd.VersionSchemaInput{Schema: "semver"}, "0.0.0"},
+		{"semver bump", cmd.VersionSchemaInput{Schema: "semver", Current: "1.2.3"}, "1.2.4"},
+		{"semver bumpminor", cmd.VersionSchemaInput{Schema: "semver", Current: "1.2.3", Action: "bumpminor"}, "1.3.0"},
+		{"semver bumpmajor", cmd.VersionSchemaInput{Schema: "semver", Current: "1.2.3", Action: "bumpmajor"}, "2.0.0"},
+		{"semver modifier and metadata", cmd.VersionSchemaInput{Schema: "semver", Current: "1.2.3-rc1", Modifier: "rc2", Metadata: "build.7"}, "1.2.4-rc2+build.7"},
+		{"semver pin changed", cmd.VersionSchemaInput{Schema: "semver", Current: "1.2.3", Pin: "2.0.Patch"}, "2.0.0"},
+		{"semver pin kept", cmd.VersionSchemaInput{Schema: "semver", Current: "2.0.3", Pin: "2.0.Patch"}, "2.0.4"},
+		{"calver same month", cmd.VersionSchemaInput{Schema: "calver_ubuntu", Current: "26.10.4"}, "26.10.5"},
+		{"calver new month", cmd.VersionSchemaInput{Schema: "calver_ubuntu", Current: "26.09.4"}, "26.10.0"},
+		{"calver initial", cmd.VersionSchemaInput{Schema: "YYYY.0M.Micro"}, "2026.10.0"},
+		{"calver reliza", cmd.VersionSchemaInput{Schema: "calver_reliza", Current: "2026.10.Stable.3"}, "2026.10.Stable.4"},
+		{"branch same", cmd.VersionSchemaInput{Schema: "Branch.Micro", Current: "feature-x.3", Branch: "feature/x"}, "feature-x.4"},
+		{"branch new", cmd.VersionSchemaInput{Schema: "Branch.Micro", Current: "feature-x.3", Branch: "feature/login_page"}, "feature-login-page.0"},
+		{"buildid", cmd.VersionSchemaInput{Schema: "Major.Minor.Patch-Buildid", Current: "1.0.0-41", BuildId: "42"}, "1.0.1-42"},
+	}
+	for _, c := range cases {
+		c.input.Now = versionTestDate
+		actual, err := cmd.NextVersion(c.input)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.name, err)
+		}
+		if actual != c.expected {
+			t.Fatalf("%s: expected %s, actual = %s", c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestNextVersionErrors(t *testing.T) {
+	cases := []cmd.VersionSchemaInput{
+		{Schema: "semver", Current: "not-a-version"},
+		{Schema: "calver_ubuntu", Current: "26.10.4", Action: "bumpmajor"},
+		{Schema: "semver", Current: "1.2.3", Action: "bumpsideways"},
+		{Schema: "Branch.Micro"},
+	}
+	for _, c := range cases {
+		c.Now = versionTestDate
+		if v, err := cmd.NextVersion(c); err == nil {
+			t.Fatalf("expected error for %+v, got %s", c, v)
+		}
+	}
+}